@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/MohakGupta2004/taskgo/internal/config"
+	"github.com/MohakGupta2004/taskgo/internal/task"
 	"github.com/MohakGupta2004/taskgo/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -17,6 +18,23 @@ var addCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		group, _ := cmd.Flags().GetString("group")
 		validity, _ := cmd.Flags().GetString("validity")
+		dueFlag, _ := cmd.Flags().GetString("due")
+		dependsFlag, _ := cmd.Flags().GetString("depends")
+
+		due, err := task.ParseDue(dueFlag, time.Now())
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render(err.Error()))
+			return
+		}
+
+		var dependsOn []string
+		if dependsFlag != "" {
+			dependsOn, err = resolveDependencies(dependsFlag)
+			if err != nil {
+				fmt.Println(ui.ErrorStyle.Render(err.Error()))
+				return
+			}
+		}
 
 		if group == "" {
 			ctx, err := config.LoadContext()
@@ -36,8 +54,7 @@ var addCmd = &cobra.Command{
 			}
 		}
 
-		err := taskManager.Add(title, group, validity)
-		if err != nil {
+		if _, err := taskManager.Add(title, group, validity, due, dependsOn); err != nil {
 			fmt.Println(ui.ErrorStyle.Render("Error adding task: " + err.Error()))
 			return
 		}
@@ -48,5 +65,7 @@ var addCmd = &cobra.Command{
 func init() {
 	addCmd.Flags().StringP("group", "g", "", "Group for the task")
 	addCmd.Flags().StringP("validity", "v", "", "Validity duration (e.g. 1h, 30m)")
+	addCmd.Flags().StringP("due", "d", "", "Due date (e.g. today, friday, 3d, 2006-01-02)")
+	addCmd.Flags().String("depends", "", "Tasks this task depends on (e.g. 3,5 or a UUID prefix)")
 	rootCmd.AddCommand(addCmd)
 }
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/MohakGupta2004/taskgo/internal/stats"
+	"github.com/MohakGupta2004/taskgo/internal/task"
+	"github.com/MohakGupta2004/taskgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var agendaCmd = &cobra.Command{
+	Use:   "agenda",
+	Short: "Show upcoming due dates and expirations day by day",
+	Long: `Show the deadlines of open tasks for the coming days: due dates and the
+moment a task's validity runs out and it is removed. Overdue tasks are
+listed first.
+
+Examples:
+  taskgo agenda
+  taskgo agenda --days 14 -g work`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		days, _ := cmd.Flags().GetInt("days")
+		groups, _ := cmd.Flags().GetStringSlice("group")
+		if days < 1 {
+			fmt.Println(ui.ErrorStyle.Render("--days must be positive"))
+			return
+		}
+
+		tasks, err := taskManager.Select(&task.Selector{Groups: groups})
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading tasks: " + err.Error()))
+			return
+		}
+
+		now := time.Now()
+		today := task.StartOfDay(now)
+		events := task.Events(tasks, today.AddDate(0, 0, days))
+
+		fmt.Println(ui.RenderTitle("Agenda"))
+
+		var overdue []task.Event
+		byDay := make(map[stats.DayKey][]task.Event)
+		for _, e := range events {
+			if e.Time.Before(now) {
+				overdue = append(overdue, e)
+				continue
+			}
+			day := stats.Day(e.Time)
+			byDay[day] = append(byDay[day], e)
+		}
+
+		if len(overdue) > 0 {
+			fmt.Println(ui.ErrorStyle.Bold(true).Render(fmt.Sprintf("Overdue (%d)", len(overdue))))
+			for _, e := range overdue {
+				fmt.Println(renderEvent(e, "Mon 02 Jan 15:04"))
+			}
+			fmt.Println()
+		}
+
+		for i := 0; i < days; i++ {
+			day := today.AddDate(0, 0, i)
+			label := day.Format("Mon 02 Jan")
+			switch i {
+			case 0:
+				label = "Today · " + label
+			case 1:
+				label = "Tomorrow · " + label
+			}
+			fmt.Println(ui.TreeBranchStyle.Render(label))
+
+			if len(byDay[stats.Day(day)]) == 0 {
+				fmt.Println(ui.SecondaryStyle.Render("  No deadlines"))
+			}
+			for _, e := range byDay[stats.Day(day)] {
+				fmt.Println(renderEvent(e, "15:04"))
+			}
+			fmt.Println()
+		}
+	},
+}
+
+// renderEvent prints an agenda line: time, kind, task and group.
+func renderEvent(e task.Event, layout string) string {
+	kind := ui.WarningStyle.Render(fmt.Sprintf("%-7s", e.Kind))
+	if e.Kind == task.EventExpires {
+		kind = ui.SecondaryStyle.Render(fmt.Sprintf("%-7s", e.Kind))
+	}
+
+	group := e.Task.Group
+	if group == "" {
+		group = "General"
+	}
+
+	return fmt.Sprintf("  %s  %s  %s %s %s",
+		ui.SecondaryStyle.Render(e.Time.Format(layout)),
+		kind,
+		ui.SecondaryStyle.Render(fmt.Sprintf("#%d", e.Task.ID)),
+		renderTitle(e.Task),
+		ui.SecondaryStyle.Render("["+group+"]"))
+}
+
+func init() {
+	agendaCmd.Flags().Int("days", 7, "Number of days to show")
+	agendaCmd.Flags().StringSliceP("group", "g", nil, "Only include these groups")
+	rootCmd.AddCommand(agendaCmd)
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/MohakGupta2004/taskgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var archiveCmd = &cobra.Command{
+	Use:   "archive [ids|filter...]",
+	Short: "Hide tasks from lists and reports without deleting them",
+	Long: `Archive one or more tasks. Archived tasks keep their history but are left
+out of list, reports, next and search unless asked for (list --archived,
+search --all, or the archived:yes filter).
+
+` + selectorHelp + `
+
+Examples:
+  taskgo archive 4
+  taskgo archive status:todo idle:90d --yes`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		tasks, err := selectTasks(args)
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error selecting tasks: " + err.Error()))
+			return
+		}
+
+		if !confirmBulk("archive", tasks, yes) {
+			return
+		}
+
+		if err := taskManager.ArchiveMany(taskIDs(tasks)); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error archiving task: " + err.Error()))
+			return
+		}
+		fmt.Println(ui.SuccessStyle.Render(pluralTasks(len(tasks)) + " archived successfully!"))
+	},
+}
+
+var unarchiveCmd = &cobra.Command{
+	Use:   "unarchive [ids|filter...]",
+	Short: "Restore archived tasks",
+	Long: `Bring archived tasks back into lists and reports.
+
+Examples:
+  taskgo unarchive 4
+  taskgo unarchive archived:yes group:work`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		tasks, err := selectTasks(args)
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error selecting tasks: " + err.Error()))
+			return
+		}
+
+		if !confirmBulk("unarchive", tasks, yes) {
+			return
+		}
+
+		if err := taskManager.UnarchiveMany(taskIDs(tasks)); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error restoring task: " + err.Error()))
+			return
+		}
+		fmt.Println(ui.SuccessStyle.Render(pluralTasks(len(tasks)) + " restored successfully!"))
+	},
+}
+
+func init() {
+	archiveCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt for bulk archiving")
+	unarchiveCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt for bulk restores")
+	rootCmd.AddCommand(archiveCmd)
+	rootCmd.AddCommand(unarchiveCmd)
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MohakGupta2004/taskgo/internal/task"
+	"github.com/MohakGupta2004/taskgo/internal/ui"
+)
+
+// bulkConfirmThreshold is the number of tasks above which a bulk operation
+// shows a preview and asks for confirmation.
+const bulkConfirmThreshold = 3
+
+const selectorHelp = `Tasks can be selected by ID, ID list or range, UUID prefix, or filter expression:
+  3                  a single task
+  3,5,9-14           a list of IDs and ranges
+  4f9c               a unique UUID prefix (at least 4 characters)
+  status:todo        filter by status (todo, in-progress, completed)
+  group:work         filter by group
+  status:open        todo and in-progress tasks
+  tag:backend        filter by tag
+  title:deploy       filter by title substring
+  due:today          due today or earlier (also tomorrow, week, overdue, any, none)
+  completed:7d       completed within the last 7 days
+  idle:3d            unchanged for at least 3 days
+  uuid:1234          a UUID prefix made only of digits
+  archived:yes       archived tasks (also no, any); hidden unless referenced
+Filters can be combined: status:todo group:work`
+
+// selectTasks resolves command arguments into the tasks they select.
+func selectTasks(args []string) ([]task.Task, error) {
+	sel, err := task.ParseSelector(args)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := taskManager.Select(sel)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("no matching tasks found")
+	}
+	return tasks, nil
+}
+
+// confirmBulk previews the affected tasks and asks before applying an action
+// to more than bulkConfirmThreshold tasks. It returns true when the caller
+// should proceed.
+func confirmBulk(action string, tasks []task.Task, yes bool) bool {
+	if yes || len(tasks) <= bulkConfirmThreshold {
+		return true
+	}
+
+	fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("This will %s the following %d tasks:", action, len(tasks))))
+	for _, t := range tasks {
+		fmt.Println(ui.SecondaryStyle.Render(fmt.Sprintf("  %4d  %s [%s]", t.ID, t.Title, t.Status)))
+	}
+	if !promptYesNo("Continue?", false) {
+		fmt.Println(ui.WarningStyle.Render("Aborted."))
+		return false
+	}
+	return true
+}
+
+// resolveDependencies turns a comma separated list of task references into
+// UUIDs. "none" clears the dependencies.
+func resolveDependencies(list string) ([]string, error) {
+	if list == "none" {
+		return nil, nil
+	}
+
+	var uuids []string
+	for _, ref := range strings.Split(list, ",") {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			continue
+		}
+		t, err := taskManager.Resolve(ref)
+		if err != nil {
+			return nil, fmt.Errorf("dependency '%s': %w", ref, err)
+		}
+		uuids = append(uuids, t.UUID)
+	}
+	return uuids, nil
+}
+
+func taskIDs(tasks []task.Task) []int {
+	ids := make([]int, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+	}
+	return ids
+}
+
+// pluralTasks returns "Task" or "N tasks" for success messages.
+func pluralTasks(n int) string {
+	if n == 1 {
+		return "Task"
+	}
+	return fmt.Sprintf("%d tasks", n)
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/MohakGupta2004/taskgo/internal/config"
+	"github.com/MohakGupta2004/taskgo/internal/stats"
+	"github.com/MohakGupta2004/taskgo/internal/task"
+	"github.com/MohakGupta2004/taskgo/internal/ui"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+)
+
+// chartHeight is the number of rows used by the burndown charts.
+const chartHeight = 10
+
+var burndownCmd = &cobra.Command{
+	Use:   "burndown",
+	Short: "Show burndown and cumulative flow charts for a group",
+	Long: `Reconstruct how many tasks of a group were open, in progress and completed on
+each day from the task history, and draw a burndown line chart and a
+cumulative flow diagram. Tasks moved into or out of the group count only
+for the days they were in it. Removed tasks are not part of the history.
+
+Examples:
+  taskgo burndown -g sprint-12
+  taskgo burndown -g sprint-12 --days 21 --csv sprint.csv --svg sprint.svg`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		group, _ := cmd.Flags().GetString("group")
+		days, _ := cmd.Flags().GetInt("days")
+		csvPath, _ := cmd.Flags().GetString("csv")
+		svgPath, _ := cmd.Flags().GetString("svg")
+
+		if days < 2 {
+			fmt.Println(ui.ErrorStyle.Render("--days must be at least 2"))
+			return
+		}
+
+		if group == "" {
+			group = "General"
+			if ctx, err := config.LoadContext(); err == nil && ctx.CurrentGroup != "" {
+				group = ctx.CurrentGroup
+			}
+		}
+
+		// Every task is read since tasks moved out of the group still count
+		// for the days they were in it
+		tasks, err := taskManager.Select(&task.Selector{Archived: "any"})
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading tasks: " + err.Error()))
+			return
+		}
+
+		now := time.Now()
+		flow := stats.CumulativeFlow(tasks, group, stats.DailyPoints(days, now))
+		if !slices.ContainsFunc(flow, func(p stats.FlowPoint) bool { return p.Total() > 0 }) {
+			fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("No tasks in group '%s'.", group)))
+			return
+		}
+
+		open := make([]int, len(flow))
+		completed := make([]int, len(flow))
+		inProgress := make([]int, len(flow))
+		todo := make([]int, len(flow))
+		for i, p := range flow {
+			open[i] = p.Open()
+			completed[i] = p.Completed
+			inProgress[i] = p.InProgress
+			todo[i] = p.Todo
+		}
+
+		colWidth := min(max((ui.TerminalWidth()-10)/days, 1), 3)
+		axis := dateAxis(flow[0].Time, flow[len(flow)-1].Time, days*colWidth)
+
+		fmt.Println(ui.RenderTitle(fmt.Sprintf("Burndown: %s", group)))
+		fmt.Println(ui.TreeBranchStyle.Render("Open tasks"))
+		fmt.Println(ui.LineChart(open, chartHeight, colWidth))
+		fmt.Println(axis)
+		fmt.Println()
+
+		fmt.Println(ui.TreeBranchStyle.Render("Cumulative flow"))
+		fmt.Println(ui.StackedChart(
+			[][]int{completed, inProgress, todo},
+			[]lipgloss.Color{ui.SuccessColor, ui.PrimaryColor, ui.OrangeColor},
+			chartHeight, colWidth,
+		))
+		fmt.Println(axis)
+		fmt.Println("   " +
+			ui.StatusCompletedStyle.Render("█ completed") + "  " +
+			ui.StatusInProgressStyle.Render("█ in-progress") + "  " +
+			ui.StatusTodoStyle.Render("█ todo"))
+
+		last := flow[len(flow)-1]
+		fmt.Println()
+		fmt.Println(ui.SecondaryStyle.Render(fmt.Sprintf("Now: %d open (%d in progress), %d completed", last.Open(), last.InProgress, last.Completed)))
+
+		if csvPath != "" {
+			if err := exportFlow(csvPath, func(f *os.File) error { return stats.WriteCSV(f, flow) }); err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Error writing CSV: " + err.Error()))
+				return
+			}
+			fmt.Println(ui.SuccessStyle.Render("CSV written to " + csvPath))
+		}
+
+		if svgPath != "" {
+			title := fmt.Sprintf("%s: cumulative flow and burndown", group)
+			if err := exportFlow(svgPath, func(f *os.File) error { return stats.WriteSVG(f, title, flow) }); err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Error writing SVG: " + err.Error()))
+				return
+			}
+			fmt.Println(ui.SuccessStyle.Render("SVG written to " + svgPath))
+		}
+	},
+}
+
+// dateAxis labels the first and last sample under a chart of the given width.
+func dateAxis(from, to time.Time, width int) string {
+	left := from.Format("02 Jan")
+	right := to.Format("02 Jan")
+	gap := max(width-len(left)-len(right)+2, 1)
+	// Offset by the y axis label and tick.
+	return ui.SecondaryStyle.Render("   " + left + strings.Repeat(" ", gap) + right)
+}
+
+func exportFlow(path string, write func(f *os.File) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func init() {
+	burndownCmd.Flags().StringP("group", "g", "", "Group to chart (defaults to the current group)")
+	burndownCmd.Flags().Int("days", 14, "Number of days to show")
+	burndownCmd.Flags().String("csv", "", "Also export the data points as CSV to this file")
+	burndownCmd.Flags().String("svg", "", "Also export the charts as SVG to this file")
+	rootCmd.AddCommand(burndownCmd)
+}
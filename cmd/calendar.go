@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/MohakGupta2004/taskgo/internal/stats"
+	"github.com/MohakGupta2004/taskgo/internal/task"
+	"github.com/MohakGupta2004/taskgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var calendarCmd = &cobra.Command{
+	Use:   "calendar [YYYY-MM]",
+	Short: "Show a month calendar with the number of deadlines per day",
+	Long: `Show a month grid with the number of open task deadlines (due dates and
+validity expirations) on each day, followed by the deadlines themselves.
+Counts on days with overdue tasks are shown in red. Defaults to this month.
+
+Examples:
+  taskgo calendar
+  taskgo calendar 2025-12 -g work`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		groups, _ := cmd.Flags().GetStringSlice("group")
+
+		now := time.Now()
+		month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		if len(args) == 1 {
+			parsed, err := time.ParseInLocation("2006-01", args[0], now.Location())
+			if err != nil {
+				fmt.Println(ui.ErrorStyle.Render(fmt.Sprintf("Invalid month '%s'. Use YYYY-MM", args[0])))
+				return
+			}
+			month = parsed
+		}
+		end := month.AddDate(0, 1, 0)
+
+		tasks, err := taskManager.Select(&task.Selector{Groups: groups})
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading tasks: " + err.Error()))
+			return
+		}
+
+		counts := make(map[stats.DayKey]int)
+		overdue := make(map[stats.DayKey]bool)
+		var inMonth []task.Event
+		for _, e := range task.Events(tasks, end) {
+			if e.Time.Before(month) {
+				continue
+			}
+			day := stats.Day(e.Time)
+			counts[day]++
+			if e.Time.Before(now) {
+				overdue[day] = true
+			}
+			inMonth = append(inMonth, e)
+		}
+
+		fmt.Println(ui.Calendar(month, now,
+			func(day time.Time) int { return counts[stats.Day(day)] },
+			func(day time.Time) bool { return overdue[stats.Day(day)] }))
+		fmt.Println()
+
+		if len(inMonth) == 0 {
+			fmt.Println(ui.SecondaryStyle.Render("No deadlines this month."))
+			return
+		}
+		for _, e := range inMonth {
+			fmt.Println(renderEvent(e, "Mon 02 15:04"))
+		}
+	},
+}
+
+func init() {
+	calendarCmd.Flags().StringSliceP("group", "g", nil, "Only include these groups")
+	rootCmd.AddCommand(calendarCmd)
+}
@@ -14,10 +14,7 @@ var checkoutCmd = &cobra.Command{
 	Short: "Switch the active task group",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		groupName := args[0]
-		if strings.EqualFold(groupName, "default") || strings.EqualFold(groupName, "general") {
-			groupName = ""
-		}
+		groupName := contextGroup(args[0])
 
 		ctx, err := config.LoadContext()
 		if err != nil {
@@ -31,14 +28,27 @@ var checkoutCmd = &cobra.Command{
 			return
 		}
 
-		displayGroup := groupName
-		if displayGroup == "" {
-			displayGroup = "General"
-		}
-		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("Switched to group '%s'", displayGroup)))
+		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("Switched to group '%s'", groupDisplayName(groupName))))
 	},
 }
 
+// contextGroup converts a group name as typed into the value stored as the
+// current group, where the default group is empty.
+func contextGroup(name string) string {
+	if strings.EqualFold(name, "default") || strings.EqualFold(name, "general") {
+		return ""
+	}
+	return name
+}
+
+// groupDisplayName names the default group "General".
+func groupDisplayName(name string) string {
+	if name == "" || strings.EqualFold(name, "default") {
+		return "General"
+	}
+	return name
+}
+
 func init() {
 	rootCmd.AddCommand(checkoutCmd)
 }
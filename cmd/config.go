@@ -0,0 +1,326 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/MohakGupta2004/taskgo/internal/config"
+	"github.com/MohakGupta2004/taskgo/internal/editor"
+	"github.com/MohakGupta2004/taskgo/internal/plan"
+	"github.com/MohakGupta2004/taskgo/internal/task"
+	"github.com/MohakGupta2004/taskgo/internal/timer"
+	"github.com/MohakGupta2004/taskgo/internal/ui"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Show and change settings",
+	Long: `Show and change the settings in taskgo's config file.
+
+The file is $XDG_CONFIG_HOME/taskgo/config.yaml (~/.config/taskgo/config.yaml
+if XDG_CONFIG_HOME is unset). If $TASKGO_HOME is set, both the config file
+and the data files live in that directory instead of ~/.taskgo.
+
+Keys are dotted paths such as timer.pomodoro or theme.colors.primary.
+Run 'taskgo config list' to see them all.`,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get [key]",
+	Short: "Print the value of a setting",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading config: " + err.Error()))
+			return
+		}
+
+		value, err := config.Lookup(cfg, args[0])
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render(err.Error() + ". See 'taskgo config list'"))
+			return
+		}
+		if value.Kind == yaml.ScalarNode {
+			fmt.Println(value.Value)
+			return
+		}
+		enc := yaml.NewEncoder(os.Stdout)
+		enc.SetIndent(2)
+		if err := enc.Encode(value); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error formatting value: " + err.Error()))
+		}
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set [key] [value]",
+	Short: "Change a setting",
+	Long: `Change a setting. The value is read as YAML, so lists and sections can be
+given inline. Comments in the config file are kept.
+
+Examples:
+  taskgo config set timer.pomodoro 50m
+  taskgo config set timer.presets.deep 90m
+  taskgo config set theme.name nord
+  taskgo config set defaults.list_columns "[id, title, due]"
+  taskgo config set notifier.command "paplay ~/bell.oga"`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		key, value := args[0], args[1]
+		doc, err := config.Document()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading config: " + err.Error()))
+			return
+		}
+
+		if err := config.SetKey(doc, key, value); err != nil {
+			fmt.Println(ui.ErrorStyle.Render(err.Error() + ". See 'taskgo config list'"))
+			return
+		}
+		cfg, err := config.Decode(doc)
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render(fmt.Sprintf("Cannot set '%s': %s", key, err.Error())))
+			return
+		}
+		if err := checkSettings(cfg); err != nil {
+			fmt.Println(ui.ErrorStyle.Render(err.Error()))
+			return
+		}
+
+		if err := config.WriteDocument(doc); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error saving config: " + err.Error()))
+			return
+		}
+		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("%s set to %s", key, value)))
+	},
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all settings",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading config: " + err.Error()))
+			return
+		}
+
+		settings, err := config.List(cfg)
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error listing settings: " + err.Error()))
+			return
+		}
+		defaults, err := config.List(config.Default())
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error listing settings: " + err.Error()))
+			return
+		}
+		defaultValues := make(map[string]string)
+		for _, s := range defaults {
+			defaultValues[s.Key] = s.Value
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Key", "Value"})
+		table.SetBorder(true)
+		table.SetCenterSeparator("|")
+		table.SetColumnSeparator("|")
+		table.SetRowSeparator("-")
+		table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+		table.SetAlignment(tablewriter.ALIGN_LEFT)
+		table.SetAutoWrapText(false)
+		changed := false
+		for _, s := range settings {
+			key := s.Key
+			if v, ok := defaultValues[s.Key]; !ok || v != s.Value {
+				key += " *"
+				changed = true
+			}
+			table.Append([]string{key, s.Value})
+		}
+		table.Render()
+		if changed {
+			fmt.Println(ui.SecondaryStyle.Render("* changed from the default"))
+		}
+
+		if path, err := config.Path(); err == nil {
+			fmt.Println(ui.SecondaryStyle.Render("Config file: " + path))
+		}
+	},
+}
+
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Open the config file in your editor",
+	Long:  `Open the config file in $VISUAL or $EDITOR. The file is only saved if it is valid; otherwise the editor can be reopened.`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		doc, err := config.Document()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading config: " + err.Error()))
+			return
+		}
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(doc); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error preparing config: " + err.Error()))
+			return
+		}
+		original := buf.Bytes()
+		if path, err := config.Path(); err == nil {
+			if data, err := os.ReadFile(path); err == nil {
+				original = data
+			}
+		}
+
+		content := original
+		for {
+			edited, err := editor.Edit(content, "taskgo-*.yaml")
+			if err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Error running editor: " + err.Error()))
+				return
+			}
+
+			if bytes.Equal(edited, original) {
+				fmt.Println(ui.WarningStyle.Render("No changes made."))
+				return
+			}
+
+			cfg, err := config.Parse(edited)
+			if err == nil {
+				err = checkSettings(cfg)
+			}
+			if err == nil {
+				if err := config.Write(edited); err != nil {
+					fmt.Println(ui.ErrorStyle.Render("Error saving config: " + err.Error()))
+					return
+				}
+				fmt.Println(ui.SuccessStyle.Render("Config saved."))
+				return
+			}
+
+			fmt.Println(ui.ErrorStyle.Render("The edited config is invalid:"))
+			for _, line := range strings.Split(err.Error(), "\n") {
+				fmt.Println(ui.ErrorStyle.Render("  " + line))
+			}
+			if !promptYesNo("Reopen editor?", true) {
+				fmt.Println(ui.WarningStyle.Render("Changes discarded."))
+				return
+			}
+			content = edited
+		}
+	},
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the config file for mistakes",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		path, err := config.Path()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error locating config: " + err.Error()))
+			return
+		}
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("No config file at %s; the defaults are used.", path)))
+			return
+		}
+
+		cfg, err := config.Load()
+		if err == nil {
+			err = checkSettings(cfg)
+		}
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render(path + " is invalid:"))
+			for _, line := range strings.Split(err.Error(), "\n") {
+				fmt.Println(ui.ErrorStyle.Render("  " + line))
+			}
+			return
+		}
+		fmt.Println(ui.SuccessStyle.Render(path + " is valid."))
+	},
+}
+
+// checkSettings validates cfg, including the settings only the commands
+// know how to check.
+func checkSettings(cfg *config.Config) error {
+	var problems []string
+	if err := cfg.Validate(); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	if _, ok := ui.Themes[cfg.Theme.Name]; !ok {
+		problems = append(problems, fmt.Sprintf("theme.name: unknown theme '%s'. Use: %s", cfg.Theme.Name, strings.Join(ui.ThemeNames(), ", ")))
+	}
+	if _, err := plan.ParseWorkingHours(cfg.Defaults.WorkingHours); err != nil {
+		problems = append(problems, "defaults.working_hours: "+err.Error())
+	}
+	if _, err := task.ParseSort(cfg.Defaults.ListSort); err != nil {
+		problems = append(problems, "defaults.list_sort: "+err.Error())
+	}
+	if _, err := parseColumns(cfg.Defaults.ListColumns); err != nil {
+		problems = append(problems, "defaults.list_columns: "+err.Error())
+	}
+	names := make([]string, 0, len(cfg.Reports))
+	for name := range cfg.Reports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := validateReport(cfg.Reports[name]); err != nil {
+			problems = append(problems, fmt.Sprintf("reports.%s: %s", name, err.Error()))
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+// settingsProblem returns why the settings of this run are not what the
+// config file says, if they are not.
+func settingsProblem() error {
+	if err := config.LoadError(); err != nil {
+		return err
+	}
+	return checkSettings(config.Current())
+}
+
+// applySettings configures the theme and timer notifications from the
+// config file.
+func applySettings() {
+	settings := config.Current()
+
+	theme, ok := ui.Themes[settings.Theme.Name]
+	if !ok {
+		theme = ui.Themes["default"]
+	}
+	ui.ApplyTheme(theme.WithColors(settings.Theme.Colors))
+
+	timer.Notify = timer.Notifier{
+		Beeps:   settings.Notifier.Beeps,
+		Desktop: settings.Notifier.Desktop,
+		Command: settings.Notifier.Command,
+	}
+}
+
+func init() {
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configEditCmd)
+	configCmd.AddCommand(configValidateCmd)
+	rootCmd.AddCommand(configCmd)
+}
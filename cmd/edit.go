@@ -1,30 +1,47 @@
 package cmd
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/MohakGupta2004/taskgo/internal/config"
+	"github.com/MohakGupta2004/taskgo/internal/editor"
+	"github.com/MohakGupta2004/taskgo/internal/task"
 	"github.com/MohakGupta2004/taskgo/internal/ui"
 	"github.com/spf13/cobra"
 )
 
 var editCmd = &cobra.Command{
-	Use:   "edit [id] [new title]",
-	Short: "Edit task title or validity",
-	Long: `Edit a task's title or validity.
+	Use:   "edit [id|uuid] [new title]",
+	Short: "Edit a task's title, validity or all fields in $EDITOR",
+	Long: `Edit a task's title or validity, or open the whole task in $EDITOR.
+
+Without a new title the task is opened in $EDITOR as a document with YAML
+front matter (title, group, status, valid_until, due, tags) followed by the
+notes. Invalid fields are reported and the editor can be reopened.
 
 Examples:
+  taskgo edit 1                            # Edit all fields in $EDITOR
+  taskgo edit 1 --editor                   # Same as above
   taskgo edit 1 "New task title"           # Edit task title
   taskgo edit 1 --validity 2h              # Edit task validity
   taskgo edit 1 --validity none            # Remove task validity
+  taskgo edit 3,5,9-14 --validity 1h       # Edit validity of several tasks
+  taskgo edit status:todo group:work -v 8h # Edit validity of matching tasks
+  taskgo edit 1 --due friday               # Set the due date
+  taskgo edit 4 --depends 2,3              # Task 4 waits for tasks 2 and 3
   taskgo edit --group work --validity 4h   # Edit group validity`,
 	Args: cobra.MinimumNArgs(0),
 	Run: func(cmd *cobra.Command, args []string) {
 		groupFlag, _ := cmd.Flags().GetString("group")
 		validityFlag, _ := cmd.Flags().GetString("validity")
+		yesFlag, _ := cmd.Flags().GetBool("yes")
+		editorFlag, _ := cmd.Flags().GetBool("editor")
+		dueFlag, _ := cmd.Flags().GetString("due")
+		dependsFlag, _ := cmd.Flags().GetString("depends")
 
 		// Edit group validity
 		if groupFlag != "" {
@@ -47,27 +64,27 @@ Examples:
 				return
 			}
 
-			// Update the group validity in context for future tasks
-			ctx, err := config.LoadContext()
+			// Update the group validity in the config for future tasks
+			cfg, err := config.Load()
 			if err != nil {
-				fmt.Println(ui.ErrorStyle.Render("Error loading context: " + err.Error()))
+				fmt.Println(ui.ErrorStyle.Render("Error loading config: " + err.Error()))
 				return
 			}
 
-			if ctx.GroupValidity == nil {
-				ctx.GroupValidity = make(map[string]string)
+			if cfg.Defaults.GroupValidity == nil {
+				cfg.Defaults.GroupValidity = make(map[string]string)
 			}
 
 			if validityFlag == "none" {
-				delete(ctx.GroupValidity, groupFlag)
+				delete(cfg.Defaults.GroupValidity, groupFlag)
 				fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("Removed validity for group '%s' and all its tasks", groupFlag)))
 			} else {
-				ctx.GroupValidity[groupFlag] = validityFlag
+				cfg.Defaults.GroupValidity[groupFlag] = validityFlag
 				fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("Group '%s' validity set to %s for all existing and future tasks", groupFlag, validityFlag)))
 			}
 
-			if err := config.SaveContext(ctx); err != nil {
-				fmt.Println(ui.ErrorStyle.Render("Error saving context: " + err.Error()))
+			if err := config.Save(cfg); err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Error saving config: " + err.Error()))
 				return
 			}
 			return
@@ -79,31 +96,78 @@ Examples:
 			return
 		}
 
-		id, err := strconv.Atoi(args[0])
-		if err != nil {
-			fmt.Println(ui.ErrorStyle.Render("Invalid task ID"))
-			return
-		}
+		// Edit task validity, due date and dependencies, possibly for several tasks at once
+		if validityFlag != "" || dueFlag != "" || dependsFlag != "" {
+			due, err := task.ParseDue(dueFlag, time.Now())
+			if err != nil {
+				fmt.Println(ui.ErrorStyle.Render(err.Error()))
+				return
+			}
+
+			var dependsOn []string
+			if dependsFlag != "" {
+				dependsOn, err = resolveDependencies(dependsFlag)
+				if err != nil {
+					fmt.Println(ui.ErrorStyle.Render(err.Error()))
+					return
+				}
+			}
+
+			tasks, err := selectTasks(args)
+			if err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Error selecting tasks: " + err.Error()))
+				return
+			}
 
-		// Edit task validity
-		if validityFlag != "" {
-			if err := taskManager.UpdateValidity(id, validityFlag); err != nil {
-				fmt.Println(ui.ErrorStyle.Render("Error updating task validity: " + err.Error()))
+			if !confirmBulk("edit", tasks, yesFlag) {
 				return
 			}
+
+			edit := task.TaskEdit{
+				SetValidity:     validityFlag != "",
+				Validity:        validityFlag,
+				SetDue:          dueFlag != "",
+				Due:             due,
+				SetDependencies: dependsFlag != "",
+				DependsOn:       dependsOn,
+			}
+			if err := taskManager.EditMany(taskIDs(tasks), edit); err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Error editing tasks: " + err.Error()))
+				return
+			}
+
 			if validityFlag == "none" {
-				fmt.Println(ui.SuccessStyle.Render("Task validity removed successfully!"))
-			} else {
-				fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("Task validity updated to %s!", validityFlag)))
+				fmt.Println(ui.SuccessStyle.Render(pluralTasks(len(tasks)) + " validity removed successfully!"))
+			} else if validityFlag != "" {
+				fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("%s validity updated to %s!", pluralTasks(len(tasks)), validityFlag)))
+			}
+			if dueFlag != "" {
+				if due == nil {
+					fmt.Println(ui.SuccessStyle.Render(pluralTasks(len(tasks)) + " due date removed successfully!"))
+				} else {
+					fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("%s due date set to %s!", pluralTasks(len(tasks)), due.Format(displayTimeFormat))))
+				}
+			}
+			if dependsFlag != "" {
+				fmt.Println(ui.SuccessStyle.Render(pluralTasks(len(tasks)) + " dependencies updated successfully!"))
 			}
 			return
 		}
 
-		// Edit task title
-		if len(args) < 2 {
-			fmt.Println(ui.ErrorStyle.Render("Please specify a new title or use --validity flag"))
+		target, err := taskManager.Resolve(args[0])
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error finding task: " + err.Error()))
 			return
 		}
+		id := target.ID
+
+		// Edit all fields in $EDITOR
+		if editorFlag || len(args) < 2 {
+			editTaskInEditor(id)
+			return
+		}
+
+		// Edit task title
 
 		newTitle := strings.Join(args[1:], " ")
 		if err := taskManager.UpdateTitle(id, newTitle); err != nil {
@@ -114,8 +178,93 @@ Examples:
 	},
 }
 
+// editTaskInEditor opens the task as a front matter document in $EDITOR and
+// applies the result, reopening the editor while the document is invalid.
+func editTaskInEditor(id int) {
+	t, err := taskManager.Get(id)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle.Render("Error loading task: " + err.Error()))
+		return
+	}
+
+	original, err := task.MarshalDocument(t)
+	if err != nil {
+		fmt.Println(ui.ErrorStyle.Render("Error preparing task: " + err.Error()))
+		return
+	}
+
+	doc := original
+	for {
+		edited, err := editor.Edit(doc, "taskgo-*.md")
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error running editor: " + err.Error()))
+			return
+		}
+
+		if bytes.Equal(edited, original) {
+			fmt.Println(ui.WarningStyle.Render("No changes made."))
+			return
+		}
+
+		err = taskManager.Edit(t, edited)
+		if err == nil {
+			fmt.Println(ui.SuccessStyle.Render("Task updated successfully!"))
+			return
+		}
+
+		var docErr *task.DocumentError
+		if !errors.As(err, &docErr) {
+			fmt.Println(ui.ErrorStyle.Render("Error editing task: " + err.Error()))
+			return
+		}
+
+		fmt.Println(ui.ErrorStyle.Render("The edited task is invalid:"))
+		for _, fe := range docErr.Errors {
+			fmt.Println(ui.ErrorStyle.Render(fmt.Sprintf("  %s: %s", fe.Field, fe.Message)))
+		}
+
+		if !promptYesNo("Reopen editor?", true) {
+			fmt.Println(ui.WarningStyle.Render("Changes discarded."))
+			return
+		}
+		doc = annotateDocumentErrors(edited, docErr)
+	}
+}
+
+const documentErrorPrefix = "# error: "
+
+// annotateDocumentErrors adds the field errors as YAML comments below the
+// opening front matter delimiter, replacing those of a previous attempt.
+func annotateDocumentErrors(doc []byte, docErr *task.DocumentError) []byte {
+	var kept []string
+	for _, line := range strings.SplitAfter(string(doc), "\n") {
+		if !strings.HasPrefix(line, documentErrorPrefix) {
+			kept = append(kept, line)
+		}
+	}
+
+	var notes []string
+	for _, fe := range docErr.Errors {
+		notes = append(notes, documentErrorPrefix+fe.Field+": "+fe.Message+"\n")
+	}
+
+	insertAt := 0
+	if len(kept) > 0 && strings.TrimSpace(kept[0]) == "---" {
+		insertAt = 1
+	}
+
+	result := append([]string{}, kept[:insertAt]...)
+	result = append(result, notes...)
+	result = append(result, kept[insertAt:]...)
+	return []byte(strings.Join(result, ""))
+}
+
 func init() {
 	editCmd.Flags().StringP("validity", "v", "", "Set or update validity duration (use 'none' to remove)")
+	editCmd.Flags().StringP("due", "d", "", "Set or update the due date (use 'none' to remove)")
+	editCmd.Flags().String("depends", "", "Replace dependencies (e.g. 3,5 or a UUID prefix; 'none' to remove)")
 	editCmd.Flags().StringP("group", "g", "", "Edit group validity instead of task")
+	editCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt for bulk edits")
+	editCmd.Flags().BoolP("editor", "e", false, "Edit all task fields in $EDITOR")
 	rootCmd.AddCommand(editCmd)
 }
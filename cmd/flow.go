@@ -1,15 +1,27 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"os"
 	"os/exec"
-	"runtime"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
+	"github.com/MohakGupta2004/taskgo/internal/config"
 	"github.com/MohakGupta2004/taskgo/internal/flow"
+	"github.com/MohakGupta2004/taskgo/internal/stats"
+	"github.com/MohakGupta2004/taskgo/internal/task"
 	"github.com/MohakGupta2004/taskgo/internal/timer"
 	"github.com/MohakGupta2004/taskgo/internal/ui"
+	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 )
 
@@ -19,13 +31,26 @@ var flowCmd = &cobra.Command{
 	Long:  `Create and run focused work flows with associated resources (links, apps).`,
 }
 
+// loadFlows creates the flow manager and warns about project flow files
+// that were skipped.
+func loadFlows() (*flow.Manager, error) {
+	m, err := flow.NewManager()
+	if err != nil {
+		return nil, err
+	}
+	for _, problem := range m.Problems {
+		fmt.Fprintln(os.Stderr, ui.WarningStyle.Render("Skipping project flow: "+problem.Error()))
+	}
+	return m, nil
+}
+
 var flowCreateCmd = &cobra.Command{
 	Use:   "create [name]",
 	Short: "Create a new flow",
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		name := args[0]
-		m, err := flow.NewManager()
+		m, err := loadFlows()
 		if err != nil {
 			fmt.Println(ui.ErrorStyle.Render("Error initializing flow manager: " + err.Error()))
 			return
@@ -43,12 +68,85 @@ var flowCreateCmd = &cobra.Command{
 var flowAddCmd = &cobra.Command{
 	Use:   "add [name] [resource...]",
 	Short: "Add resources to a flow",
-	Args:  cobra.MinimumNArgs(2),
+	Long: `Add resources to a flow. Without --type each resource's type is guessed:
+existing paths are files or directories, links are URLs and programs on
+your PATH are apps. Use --type to be explicit:
+
+  url       a website, opened in the browser
+  app       a program; everything after the program name is its arguments
+  file      a file, opened with its default application
+  dir       a directory, opened in the file manager
+  shell     a shell command, run with sh -c
+  terminal  a terminal window started in a directory
+
+Examples:
+  taskgo flow add coding https://github.com ~/notes.txt
+  taskgo flow add coding --type app -- code --new-window ~/src/taskgo
+  taskgo flow add coding --type shell "docker compose up -d"
+  taskgo flow add coding --type terminal ~/src/taskgo
+  taskgo flow add coding --pre "git pull --ff-only"
+  taskgo flow add coding --post "git status --short"
+  taskgo flow add coding --teardown "docker compose down"
+
+--pre, --post and --teardown add the arguments as shell commands instead
+of resources. Pre-run hooks run in order before the resources are opened,
+post-run hooks after the session ends and teardown commands when the flow
+is torn down. Hooks run in the foreground and must finish: start
+long-running things such as dev servers as shell resources so they are
+tracked and stopped on teardown.`,
+	Args: cobra.MinimumNArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
 		name := args[0]
-		resources := args[1:]
+		typeFlag, _ := cmd.Flags().GetString("type")
+		stage, isCommand, err := stageFlag(cmd)
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render(err.Error()))
+			return
+		}
+
+		if isCommand {
+			m, err := loadFlows()
+			if err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Error initializing flow manager: " + err.Error()))
+				return
+			}
+			for _, command := range args[1:] {
+				if err := m.AddCommand(name, stage, command); err != nil {
+					fmt.Println(ui.ErrorStyle.Render(fmt.Sprintf("Error adding %s command '%s': %s", stage, command, err.Error())))
+					return
+				}
+			}
+			fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("Added %d %s commands to flow '%s'", len(args[1:]), stage, name)))
+			return
+		}
+
+		var resources []flow.Resource
+		if typeFlag == "" {
+			for _, res := range args[1:] {
+				resources = append(resources, flow.GuessResource(res))
+			}
+		} else {
+			resType, err := flow.ParseResourceType(typeFlag)
+			if err != nil {
+				fmt.Println(ui.ErrorStyle.Render(err.Error()))
+				return
+			}
+
+			if resType == flow.TypeApp {
+				// One app per call: the remaining arguments belong to it
+				argv := args[1:]
+				if len(argv) == 1 {
+					argv = strings.Fields(argv[0])
+				}
+				resources = append(resources, flow.Resource{Type: resType, Target: argv[0], Args: argv[1:]})
+			} else {
+				for _, res := range args[1:] {
+					resources = append(resources, flow.Resource{Type: resType, Target: res})
+				}
+			}
+		}
 
-		m, err := flow.NewManager()
+		m, err := loadFlows()
 		if err != nil {
 			fmt.Println(ui.ErrorStyle.Render("Error initializing flow manager: " + err.Error()))
 			return
@@ -59,12 +157,35 @@ var flowAddCmd = &cobra.Command{
 				fmt.Println(ui.ErrorStyle.Render(fmt.Sprintf("Error adding resource '%s': %s", res, err.Error())))
 				return
 			}
+			fmt.Println(ui.SecondaryStyle.Render(fmt.Sprintf("  %-8s %s", res.Type, res)))
 		}
 
 		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("Added %d resources to flow '%s'", len(resources), name)))
 	},
 }
 
+// stageFlag returns the hook stage selected with --pre, --post or
+// --teardown. ok is false if none is set.
+func stageFlag(cmd *cobra.Command) (stage flow.Stage, ok bool, err error) {
+	flags := []struct {
+		name  string
+		stage flow.Stage
+	}{
+		{"pre", flow.StagePreRun},
+		{"post", flow.StagePostRun},
+		{"teardown", flow.StageTeardown},
+	}
+	for _, f := range flags {
+		if set, _ := cmd.Flags().GetBool(f.name); set {
+			if ok {
+				return "", false, fmt.Errorf("use only one of --pre, --post and --teardown")
+			}
+			stage, ok = f.stage, true
+		}
+	}
+	return stage, ok, nil
+}
+
 var zenMode bool
 
 var flowRunCmd = &cobra.Command{
@@ -73,7 +194,7 @@ var flowRunCmd = &cobra.Command{
 	Args:  cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		name := args[0]
-		m, err := flow.NewManager()
+		m, err := loadFlows()
 		if err != nil {
 			fmt.Println(ui.ErrorStyle.Render("Error initializing flow manager: " + err.Error()))
 			return
@@ -84,149 +205,1540 @@ var flowRunCmd = &cobra.Command{
 			fmt.Println(ui.ErrorStyle.Render("Error loading flow: " + err.Error()))
 			return
 		}
+		if err := m.CheckTrusted(f.Name); err != nil {
+			fmt.Println(ui.ErrorStyle.Render(capitalize(err.Error())))
+			return
+		}
+		recoverBlock()
+
+		log, err := flow.OpenLog(f.Name)
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error opening flow log: " + err.Error()))
+			return
+		}
+		defer log.Close()
 
-		// Open resources
 		fmt.Println(ui.RenderTitle(fmt.Sprintf("Starting Flow: %s", f.Name)))
+		if err := f.RunHooks(flow.StagePreRun, log); err != nil {
+			fmt.Println(ui.ErrorStyle.Render(capitalize(err.Error())))
+			if f.AbortOnFailure() {
+				fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("Flow aborted. See %s", log.Name())))
+				return
+			}
+		}
+
+		restoreGroup := func() {}
+		if f.Group != "" {
+			restoreGroup = checkoutFlowGroup(f.Group)
+		}
+		// Switches back however the run ends
+		defer restoreGroup()
+
+		unblock := func() {}
+		if noBlock, _ := cmd.Flags().GetBool("no-block"); zenMode && len(f.Block) > 0 && !noBlock {
+			unblock = blockDistractions(f)
+		}
+		// Also lifts the block if anything below panics
+		defer unblock()
+
+		// Open resources
 		if zenMode {
 			fmt.Println(ui.WarningStyle.Render("🧘 Entering Zen Mode..."))
 		}
 		fmt.Println("Opening resources...")
 
-		openResources(f.Resources, zenMode)
+		session := &flow.Session{Flow: f.Name, Started: time.Now()}
+		session.Processes = openResources(f, zenMode)
+		if len(session.Processes) > 0 {
+			if err := flow.SaveSession(session); err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Error recording flow session: " + err.Error()))
+			}
+		}
+
+		plan := f.SessionPlan()
+		if cmd.Flags().Changed("duration") {
+			plan.Total, _ = cmd.Flags().GetDuration("duration")
+		}
+		start := time.Now()
+		focused, pauses, completed := runFlowTimers(f, plan)
+		unblock()
 
-		// Start a timer (indefinite or fixed? User didn't specify duration in run command, assuming indefinite or manual stop)
-		// For now, let's just use a simple "Flow Session" timer that counts UP or just shows active state.
-		// But our timer package is countdown. Let's use a default long duration like 4 hours for now, or update timer to support count up.
-		// Given the constraints, let's start a 4h timer as a "session".
-		// User said "taskgo flow run coding_flow", no duration.
-		// Let's assume a standard work block or just open resources.
-		// But user mentioned "I can break it and pause it".
-		// Let's use a generic timer for now.
+		run := flow.Run{
+			Flow:      f.Name,
+			Group:     f.Group,
+			Start:     start,
+			End:       time.Now(),
+			Focused:   focused,
+			Completed: completed,
+			Pauses:    pauses,
+		}
+		for _, p := range session.Processes {
+			run.Resources = append(run.Resources, p.Resource)
+		}
+		if err := flow.AppendHistory(run); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error recording flow run: " + err.Error()))
+		}
 
-		// TODO: Maybe ask for duration or default to open-ended?
-		// For this iteration, I'll default to 1 hour to show the UI, as `timer` requires duration.
-		// Or I can modify `timer` to support "Stopwatch" mode.
-		// Let's stick to a default duration for now to reuse `timer`.
+		if err := f.RunHooks(flow.StagePostRun, log); err != nil {
+			fmt.Println(ui.ErrorStyle.Render(capitalize(err.Error())))
+		}
 
-		t := timer.New(4*time.Hour, fmt.Sprintf("Flow: %s", f.Name))
-		t.Start()
+		teardown, _ := cmd.Flags().GetBool("teardown")
+		keep, _ := cmd.Flags().GetBool("keep")
+		endFlow(f, session, teardown, keep)
 	},
 }
 
-var flowListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List all flows",
+// runFlowTimers runs the work and break timers of a flow session and logs
+// them like pomodoros. Quitting a timer or Ctrl+C ends the session. It
+// returns the time spent working, the pauses and whether every timer ran
+// out.
+func runFlowTimers(f *flow.Flow, plan flow.SessionPlan) (focused time.Duration, pauses []timer.Pause, completed bool) {
+	var mu sync.Mutex
+	var current *timer.Timer
+	stopped := false
+
+	// Ctrl+C ends the session like 'q' so the teardown still runs
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	go func() {
+		if _, ok := <-signals; ok {
+			mu.Lock()
+			stopped = true
+			if current != nil {
+				current.Stop()
+			}
+			mu.Unlock()
+		}
+	}()
+	defer func() {
+		signal.Stop(signals)
+		close(signals)
+	}()
+
+	intervals := plan.Intervals()
+	work := 0
+	for _, iv := range intervals {
+		if !iv.Break {
+			work++
+		}
+	}
+
+	block := 0
+	for _, iv := range intervals {
+		title := fmt.Sprintf("Flow: %s", f.Name)
+		kind := timer.KindWork
+		if iv.Break {
+			title += " · Break"
+			kind = timer.KindBreak
+		} else if work > 1 {
+			block++
+			title += fmt.Sprintf(" · Work %d/%d", block, work)
+		}
+
+		t := timer.New(iv.Duration, title)
+		if f.Group != "" && !iv.Break {
+			t.Details = groupTasks(f.Group)
+		}
+
+		mu.Lock()
+		if stopped {
+			mu.Unlock()
+			return focused, pauses, false
+		}
+		current = t
+		mu.Unlock()
+
+		start := time.Now()
+		logTimer(t, kind, "")
+		if !iv.Break {
+			focused += time.Since(start) - t.Paused()
+		}
+		pauses = append(pauses, t.Pauses...)
+		if !t.Finished {
+			return focused, pauses, false
+		}
+	}
+	return focused, pauses, true
+}
+
+// flowTaskLimit caps the tasks listed on a flow's timer screen.
+const flowTaskLimit = 8
+
+// groupTasks lists the open tasks of a group, most urgent first, for the
+// timer screen.
+func groupTasks(group string) string {
+	tasks, err := taskManager.Select(&task.Selector{
+		Groups: []string{groupDisplayName(group)},
+		Status: []task.TaskStatus{task.StatusTodo, task.StatusInProgress},
+	})
+	if err != nil {
+		return ui.ErrorStyle.Render("Error loading tasks: " + err.Error())
+	}
+
+	heading := ui.TreeBranchStyle.Render(fmt.Sprintf("Open tasks in %s (%d)", groupDisplayName(group), len(tasks)))
+	if len(tasks) == 0 {
+		return heading + "\n" + ui.SecondaryStyle.Render("  Nothing left. 🎉")
+	}
+
+	all, err := taskManager.List()
+	if err != nil {
+		all = tasks
+	}
+	deps := task.NewDependencies(all)
+	now := time.Now()
+	score := make(map[string]float64, len(tasks))
+	for _, t := range tasks {
+		score[t.UUID] = task.Urgency(t, deps, contextGroup(group), now)
+	}
+	sort.SliceStable(tasks, func(i, j int) bool { return score[tasks[i].UUID] > score[tasks[j].UUID] })
+
+	lines := []string{heading}
+	for i, t := range tasks {
+		if i == flowTaskLimit {
+			lines = append(lines, ui.SecondaryStyle.Render(fmt.Sprintf("  … and %d more", len(tasks)-flowTaskLimit)))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("  %s %s", ui.SecondaryStyle.Render(fmt.Sprintf("#%d", t.ID)), renderTitle(t)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// checkoutFlowGroup checks out a flow's task group and returns a function
+// switching back to the previous group. The previous group is only
+// restored if the flow's group is still checked out.
+func checkoutFlowGroup(group string) (restore func()) {
+	restore = func() {}
+	ctx, err := config.LoadContext()
+	if err != nil {
+		fmt.Println(ui.ErrorStyle.Render("Error loading context: " + err.Error()))
+		return restore
+	}
+
+	previous := ctx.CurrentGroup
+	ctx.CurrentGroup = contextGroup(group)
+	if ctx.CurrentGroup == previous {
+		return restore
+	}
+	if err := config.SaveContext(ctx); err != nil {
+		fmt.Println(ui.ErrorStyle.Render("Error saving context: " + err.Error()))
+		return restore
+	}
+	fmt.Printf("Switched to group '%s'\n", groupDisplayName(ctx.CurrentGroup))
+
+	return func() {
+		ctx, err := config.LoadContext()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading context: " + err.Error()))
+			return
+		}
+		if ctx.CurrentGroup != contextGroup(group) {
+			return
+		}
+		ctx.CurrentGroup = previous
+		if err := config.SaveContext(ctx); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error saving context: " + err.Error()))
+			return
+		}
+		fmt.Printf("Switched back to group '%s'\n", groupDisplayName(previous))
+	}
+}
+
+var flowStatsCmd = &cobra.Command{
+	Use:   "stats [name]",
+	Short: "Show focused time and session lengths per flow",
+	Long: `Show how much focused time went into each flow per day (or per week with
+--weeks), followed by the number of sessions, how many ran to the end and
+the average session length. Focused time counts work intervals without
+pauses and is attributed to the day a session started.
+
+Examples:
+  taskgo flow stats
+  taskgo flow stats coding --days 14
+  taskgo flow stats --weeks 8`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		days, _ := cmd.Flags().GetInt("days")
+		weeks, _ := cmd.Flags().GetInt("weeks")
+		if days < 1 || weeks < 0 {
+			fmt.Println(ui.ErrorStyle.Render("--days must be positive and --weeks not negative"))
+			return
+		}
+
+		runs, err := flow.LoadHistory()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading flow history: " + err.Error()))
+			return
+		}
+		if len(args) == 1 {
+			var filtered []flow.Run
+			for _, r := range runs {
+				if r.Flow == args[0] {
+					filtered = append(filtered, r)
+				}
+			}
+			runs = filtered
+		}
+		if len(runs) == 0 {
+			fmt.Println("No flow runs recorded yet. Start one with 'taskgo flow run <name>'")
+			return
+		}
+
+		now := time.Now()
+		periods := stats.LastDays(days, now)
+		heading := fmt.Sprintf("Focused time per day (last %d days)", days)
+		if weeks > 0 {
+			periods = stats.LastWeeks(weeks, now)
+			heading = fmt.Sprintf("Focused time per week (last %d weeks)", weeks)
+		}
+
+		summaries := stats.SummariseFlows(runs)
+		names := make([]string, len(summaries))
+		for i, s := range summaries {
+			names[i] = s.Flow
+		}
+
+		fmt.Println(ui.RenderTitle("Flow Stats"))
+		fmt.Println(ui.TreeBranchStyle.Render(heading))
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader(append(append([]string{"Period"}, names...), "Total"))
+		table.SetBorder(true)
+		table.SetCenterSeparator("|")
+		table.SetColumnSeparator("|")
+		table.SetRowSeparator("-")
+		table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+		table.SetAlignment(tablewriter.ALIGN_LEFT)
+		for _, p := range periods {
+			focused := stats.FocusedIn(runs, p)
+			row := []string{p.Label}
+			var total time.Duration
+			for _, name := range names {
+				row = append(row, focusCell(focused[name]))
+				total += focused[name]
+			}
+			table.Append(append(row, focusCell(total)))
+		}
+		table.Render()
+		fmt.Println()
+
+		fmt.Println(ui.TreeBranchStyle.Render("Sessions"))
+		table = tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Flow", "Sessions", "Completed", "Focused", "Avg session", "Last run"})
+		table.SetBorder(true)
+		table.SetCenterSeparator("|")
+		table.SetColumnSeparator("|")
+		table.SetRowSeparator("-")
+		table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+		table.SetAlignment(tablewriter.ALIGN_LEFT)
+		for _, s := range summaries {
+			table.Append([]string{
+				s.Flow,
+				strconv.Itoa(s.Sessions),
+				strconv.Itoa(s.Completed),
+				formatDuration(s.Focused),
+				formatDuration(s.Average()),
+				s.Last.Format("Mon 02 Jan 15:04"),
+			})
+		}
+		table.Render()
+	},
+}
+
+// focusCell formats a focus total, showing "-" for periods without focus.
+func focusCell(d time.Duration) string {
+	if d < time.Minute {
+		return "-"
+	}
+	return formatDuration(d)
+}
+
+var flowExportCmd = &cobra.Command{
+	Use:   "export [name]",
+	Short: "Write a flow as a YAML file to share",
+	Long: `Write a flow in the YAML format read by 'taskgo flow import' and by
+project discovery (see docs/flows.md). Paths inside the project directory
+are written as {{.ProjectDir}} so the flow works in other checkouts; the
+project is the one the current directory belongs to unless --project-dir
+is given.
+
+Examples:
+  taskgo flow export coding > coding.yaml
+  taskgo flow export coding -o .taskgo/flows/coding.yaml --project-dir .`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		m, err := flow.NewManager()
+		output, _ := cmd.Flags().GetString("output")
+
+		m, err := loadFlows()
 		if err != nil {
 			fmt.Println(ui.ErrorStyle.Render("Error initializing flow manager: " + err.Error()))
 			return
 		}
 
-		flows := m.List()
-		if len(flows) == 0 {
-			fmt.Println("No flows found. Create one with 'taskgo flow create <name>'")
+		f, err := m.Get(args[0])
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading flow: " + err.Error()))
 			return
 		}
 
-		fmt.Println(ui.RenderTitle("Available Flows"))
-		for _, f := range flows {
-			fmt.Println("- " + f)
+		projectDir := m.ProjectDir
+		if cmd.Flags().Changed("project-dir") {
+			dir, _ := cmd.Flags().GetString("project-dir")
+			if projectDir, err = filepath.Abs(dir); err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Error resolving project directory: " + err.Error()))
+				return
+			}
 		}
+
+		data, err := flow.Export(f, projectDir)
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error exporting flow: " + err.Error()))
+			return
+		}
+
+		if output == "" {
+			os.Stdout.Write(data)
+			return
+		}
+		if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error writing flow: " + err.Error()))
+			return
+		}
+		if err := os.WriteFile(output, data, 0644); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error writing flow: " + err.Error()))
+			return
+		}
+		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("Flow '%s' exported to %s", f.Name, output)))
 	},
 }
 
-func openResources(resources []string, zen bool) {
-	if len(resources) == 0 {
-		return
-	}
+var flowImportCmd = &cobra.Command{
+	Use:   "import [file|dir]",
+	Short: "Add flows from YAML files to your flows",
+	Long: `Read flows from a YAML file, or from every .yaml/.yml file in a directory,
+and add them to your own flows. Placeholders such as {{.ProjectDir}} are
+filled in now: the project directory is the one containing the file's
+.taskgo/flows folder, the current directory otherwise, or --project-dir.
 
-	var urls []string
-	var apps []string
+Flows in a project's .taskgo/flows folder don't need importing: they are
+available whenever you work inside the project, once you have trusted it
+with 'taskgo flow trust'. Import one to keep or customise it.
 
-	// Separate URLs and Apps
-	for _, res := range resources {
-		// Simple heuristic: if it contains "://" or starts with "www." or has a dot and no spaces, treat as URL
-		// Otherwise treat as app
-		if strings.Contains(res, "://") || strings.HasPrefix(res, "www.") || (strings.Contains(res, ".") && !strings.Contains(res, " ")) {
-			urls = append(urls, res)
-		} else {
-			apps = append(apps, res)
+Examples:
+  taskgo flow import coding.yaml
+  taskgo flow import ~/src/app/.taskgo/flows --force`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		force, _ := cmd.Flags().GetBool("force")
+
+		files, err := flow.FlowFiles(args[0])
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error reading flows: " + err.Error()))
+			return
+		}
+		if len(files) == 0 {
+			fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("No .yaml files found in %s", args[0])))
+			return
 		}
-	}
 
-	// Launch Apps
-	for _, app := range apps {
-		path, err := exec.LookPath(app)
-		if err == nil {
-			fmt.Printf("Launching app: %s...\n", app)
-			exec.Command(path).Start()
-		} else {
-			fmt.Printf("Could not find app: %s\n", app)
+		projectDir, _ := os.Getwd()
+		if cmd.Flags().Changed("project-dir") {
+			projectDir, _ = cmd.Flags().GetString("project-dir")
+		} else if dir, ok := flow.FindProject(filepath.Dir(files[0])); ok {
+			projectDir = dir
+		}
+		if projectDir, err = filepath.Abs(projectDir); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error resolving project directory: " + err.Error()))
+			return
 		}
-	}
 
-	if len(urls) == 0 {
-		return
-	}
+		m, err := loadFlows()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error initializing flow manager: " + err.Error()))
+			return
+		}
+
+		imported := 0
+		for _, file := range files {
+			f, err := flow.LoadFile(file, flow.NewParams(projectDir))
+			if err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Error reading flow: " + err.Error()))
+				continue
+			}
+			if err := m.Import(f, force); err != nil {
+				fmt.Println(ui.ErrorStyle.Render(fmt.Sprintf("Error importing %s: %s (use --force to replace it)", file, err.Error())))
+				continue
+			}
+			fmt.Println(ui.SecondaryStyle.Render(fmt.Sprintf("  %s ← %s", f.Name, file)))
+			imported++
+		}
+		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("Imported %d flows", imported)))
+	},
+}
+
+var flowBrowserCmd = &cobra.Command{
+	Use:   "browser",
+	Short: "Configure the browsers flows open URLs in",
+	Long: `Flows open their URLs in one new window of a browser in Zen Mode, or
+whenever the flow names a browser with 'taskgo flow set --browser'.
+Browsers come from a registry of built-in entries (google-chrome, chromium,
+brave-browser, microsoft-edge, firefox) that you can override and extend.
+
+Without a configured default the desktop's default browser is used: its
+.desktop entry is looked up and the program in its Exec line is run.`,
+}
+
+var flowBrowserListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the browser registry",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		settings := config.Current()
+		browsers := flow.Browsers(settings.Flows.Browsers)
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Name", "Command", "New window", "Kiosk", "Profile"})
+		table.SetBorder(true)
+		table.SetCenterSeparator("|")
+		table.SetColumnSeparator("|")
+		table.SetRowSeparator("-")
+		table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+		table.SetAlignment(tablewriter.ALIGN_LEFT)
+		for _, name := range flow.BrowserNames(browsers) {
+			b := browsers[name]
+			label := name
+			if name == settings.Flows.Browser {
+				label += " (default)"
+			}
+			if _, configured := settings.Flows.Browsers[name]; configured {
+				label += " *"
+			}
+			command := strings.Join(append([]string{b.Binary}, b.Args...), " ")
+			if !flow.BrowserInstalled(b) {
+				command += " (not installed)"
+			}
+			table.Append([]string{label, command, strings.Join(b.NewWindow, " "), strings.Join(b.Kiosk, " "), b.Profile})
+		}
+		table.Render()
+		if len(settings.Flows.Browsers) > 0 {
+			fmt.Println(ui.SecondaryStyle.Render("* configured"))
+		}
 
-	if zen {
-		// Try to detect default browser
-		browser := ""
-		if runtime.GOOS == "linux" {
-			out, err := exec.Command("xdg-settings", "get", "default-web-browser").Output()
-			if err == nil {
-				desktopFile := strings.TrimSpace(string(out))
-				// Remove .desktop suffix if present
-				browser = strings.TrimSuffix(desktopFile, ".desktop")
+		if settings.Flows.Browser == "" {
+			if argv, err := flow.DefaultBrowser(); err == nil {
+				fmt.Printf("System default: %s\n", strings.Join(argv, " "))
+			} else {
+				fmt.Println(ui.SecondaryStyle.Render("System default: " + err.Error()))
 			}
 		}
+	},
+}
+
+var flowBrowserSetCmd = &cobra.Command{
+	Use:   "set [name]",
+	Short: "Add or change a browser in the registry",
+	Long: `Add a browser to the registry or change one. Changing a built-in browser
+starts from its built-in settings. Flags that take browser options need
+'=' since their values start with dashes.
 
-		browsers := []string{}
-		if browser != "" {
-			browsers = append(browsers, browser)
+"{profile}" in --profile-args is replaced with the --profile directory.
+
+Examples:
+  taskgo flow browser set focus --binary chromium --profile ~/.config/focus-profile
+  taskgo flow browser set firefox --kiosk=--kiosk
+  taskgo flow browser set vivaldi --binary vivaldi --new-window=--new-window \
+    --kiosk=--start-fullscreen --profile-args=--user-data-dir={profile}`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading config: " + err.Error()))
+			return
 		}
-		// Add fallbacks
-		browsers = append(browsers, "google-chrome", "chromium", "brave-browser", "firefox")
 
-		for _, b := range browsers {
-			if _, err := exec.LookPath(b); err == nil {
-				args := []string{}
-				if strings.Contains(b, "firefox") {
-					args = append([]string{"--new-window"}, urls...)
-				} else {
-					// Chrome/Chromium/Brave/Edge
-					args = append([]string{"--new-window", "--start-fullscreen"}, urls...)
-				}
+		b, known := flow.Browsers(cfg.Flows.Browsers)[name]
+		if !known {
+			b = config.Browser{Binary: name}
+		}
+		if cmd.Flags().Changed("binary") {
+			b.Binary, _ = cmd.Flags().GetString("binary")
+		}
+		for flag, dest := range map[string]*[]string{
+			"args":         &b.Args,
+			"new-window":   &b.NewWindow,
+			"kiosk":        &b.Kiosk,
+			"profile-args": &b.ProfileArgs,
+		} {
+			if cmd.Flags().Changed(flag) {
+				*dest, _ = cmd.Flags().GetStringArray(flag)
+			}
+		}
+		if cmd.Flags().Changed("profile") {
+			b.Profile, _ = cmd.Flags().GetString("profile")
+		}
 
-				fmt.Printf("Opening %d URLs in %s...\n", len(urls), b)
-				err = exec.Command(b, args...).Start()
-				if err == nil {
-					return
-				}
+		if cfg.Flows.Browsers == nil {
+			cfg.Flows.Browsers = make(map[string]config.Browser)
+		}
+		cfg.Flows.Browsers[name] = b
+		if err := config.Save(cfg); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error saving config: " + err.Error()))
+			return
+		}
+		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("Browser '%s' saved", name)))
+	},
+}
+
+var flowBrowserRemoveCmd = &cobra.Command{
+	Use:   "remove [name]",
+	Short: "Remove a configured browser",
+	Long:  `Remove a browser from the registry. A changed built-in browser returns to its built-in settings.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading config: " + err.Error()))
+			return
+		}
+		if _, ok := cfg.Flows.Browsers[args[0]]; !ok {
+			fmt.Println(ui.ErrorStyle.Render(fmt.Sprintf("Browser '%s' is not configured", args[0])))
+			return
+		}
+
+		delete(cfg.Flows.Browsers, args[0])
+		if cfg.Flows.Browser == args[0] {
+			cfg.Flows.Browser = ""
+		}
+		if err := config.Save(cfg); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error saving config: " + err.Error()))
+			return
+		}
+		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("Browser '%s' removed", args[0])))
+	},
+}
+
+var flowBrowserDefaultCmd = &cobra.Command{
+	Use:   "default [name]",
+	Short: "Set the browser flows use unless they name one",
+	Long:  `Set the browser flows use unless they name their own. Without a name the desktop's default browser is used again.`,
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading config: " + err.Error()))
+			return
+		}
+
+		name := ""
+		if len(args) == 1 {
+			name = args[0]
+			if _, ok := flow.Browsers(cfg.Flows.Browsers)[name]; !ok {
+				fmt.Println(ui.ErrorStyle.Render(fmt.Sprintf("Unknown browser '%s'. See 'taskgo flow browser list'", name)))
+				return
 			}
 		}
-		fmt.Println("Could not find a supported browser for Zen Mode. Falling back to default.")
+
+		cfg.Flows.Browser = name
+		if err := config.Save(cfg); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error saving config: " + err.Error()))
+			return
+		}
+		if name == "" {
+			fmt.Println(ui.SuccessStyle.Render("Flows use the system default browser"))
+			return
+		}
+		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("Flows use '%s' by default", name)))
+	},
+}
+
+// blockDistractions blocks the flow's domains in the hosts file and starts
+// a watchdog that lifts the block should taskgo die. The returned function
+// lifts it and may be called more than once.
+func blockDistractions(f *flow.Flow) (unblock func()) {
+	unblock = func() {}
+	hostsFile, err := loadHostsFile()
+	if err != nil {
+		fmt.Println(ui.ErrorStyle.Render("Error loading context: " + err.Error()))
+		return unblock
 	}
 
-	// Normal mode or fallback
-	for _, url := range urls {
-		fmt.Printf("Opening %s...\n", url)
-		var err error
-		switch runtime.GOOS {
-		case "linux":
-			err = exec.Command("xdg-open", url).Start()
-		case "windows":
-			err = exec.Command("rundll32", "url.dll,FileProtocolHandler", url).Start()
-		case "darwin":
-			err = exec.Command("open", url).Start()
-		default:
-			err = fmt.Errorf("unsupported platform")
+	if active, err := flow.LoadBlock(); err == nil && active != nil && !active.Orphaned() {
+		fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("Flow '%s' is already blocking sites; not blocking again", active.Flow)))
+		return unblock
+	}
+
+	block, err := flow.ApplyBlock(hostsFile, f.Name, f.Block, os.Getpid())
+	if err != nil {
+		fmt.Println(ui.ErrorStyle.Render("Error blocking sites: " + err.Error()))
+		if os.IsPermission(err) {
+			fmt.Println(ui.SecondaryStyle.Render(fmt.Sprintf("Writing %s needs administrator rights. See 'taskgo flow blocker --help' for the options.", hostsFile)))
 		}
+		if errors.Is(err, flow.ErrDamagedSection) {
+			fmt.Println(ui.SecondaryStyle.Render("Run 'taskgo flow blocker lift' to repair it."))
+		}
+		return unblock
+	}
+	fmt.Printf("Blocking %d sites until the flow ends\n", len(f.Block))
+
+	var watchdog *flow.Process
+	if exe, err := os.Executable(); err == nil {
+		p, err := flow.Start(exec.Command(exe, "flow", "blocker", "watch", strconv.Itoa(os.Getpid())), "blocker watchdog")
+		if err == nil {
+			watchdog = &p
+		}
+	}
+	if watchdog == nil {
+		fmt.Println(ui.WarningStyle.Render("Could not start the blocker watchdog. If taskgo is killed, run 'taskgo flow blocker lift'."))
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			if err := block.Lift(); err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Error unblocking sites: " + err.Error() + ". Run 'taskgo flow blocker lift'."))
+				return
+			}
+			fmt.Println("Sites unblocked")
+			if watchdog != nil {
+				flow.Terminate(*watchdog)
+			}
+		})
+	}
+}
+
+// recoverBlock lifts a block left behind by a run that died.
+func recoverBlock() {
+	block, err := flow.RecoverBlock()
+	if err != nil {
+		fmt.Println(ui.ErrorStyle.Render("Error lifting a stale site block: " + err.Error()))
+		return
+	}
+	if block != nil {
+		fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("Lifted the site block left behind by flow '%s'", block.Flow)))
+	}
+}
+
+// loadHostsFile returns the hosts file the blocker writes to.
+func loadHostsFile() (string, error) {
+	hostsFile, err := config.ExpandHome(config.Current().Flows.HostsFile)
+	if err != nil {
+		return "", err
+	}
+	if hostsFile != "" {
+		return hostsFile, nil
+	}
+	return flow.DefaultHostsFile(), nil
+}
+
+var flowBlockerCmd = &cobra.Command{
+	Use:   "blocker",
+	Short: "Manage the distraction blocker",
+	Long: `While a flow with blocked domains runs in Zen Mode, taskgo adds a marked
+section to the hosts file that points those domains at 0.0.0.0. The section
+is removed when the flow ends, on Ctrl+C and when the terminal closes. A
+watchdog process lifts it if taskgo is killed, and 'taskgo flow run' lifts
+blocks left behind by runs that died. Lines outside the section are left
+alone.
+
+Writing the system hosts file needs administrator rights, so the flow has to
+run with sudo, keeping HOME so your flows are found (an elevated terminal on
+Windows). Files taskgo creates while running as root belong to root. Do not
+make the system hosts file writable for your user instead: any program you
+run could then redirect any site. The recommended setup is a hosts file you
+own that a local resolver reads, such as a dnsmasq addn-hosts file, set with
+'taskgo flow blocker hosts-file'.
+
+The hosts file is backed up before the section is added. If the section's
+markers are damaged, lifting the block restores the backup. Browsers cache
+DNS, so pages opened shortly before a flow may still load for a minute.
+
+Examples:
+  taskgo flow set coding --block youtube.com --block reddit.com
+  taskgo flow run coding --zen
+  taskgo flow blocker status
+  taskgo flow blocker lift
+  taskgo flow blocker hosts-file ~/.config/dnsmasq/taskgo.hosts
+  sudo --preserve-env=HOME taskgo flow run coding --zen`,
+}
+
+var flowBlockerStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show whether sites are blocked",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		recoverBlock()
+
+		hostsFile, err := loadHostsFile()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading context: " + err.Error()))
+			return
+		}
+		fmt.Printf("Hosts file: %s\n", hostsFile)
+
+		block, err := flow.LoadBlock()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading block: " + err.Error()))
+			return
+		}
+		if block == nil {
+			fmt.Println(ui.SecondaryStyle.Render("No sites are blocked."))
+			return
+		}
+		fmt.Printf("Blocked by flow '%s' (pid %d) since %s:\n", block.Flow, block.PID, block.Started.Format("15:04"))
+		for _, domain := range block.Domains {
+			fmt.Println("  " + domain)
+		}
+	},
+}
+
+var flowBlockerLiftCmd = &cobra.Command{
+	Use:   "lift",
+	Short: "Remove the block section from the hosts file now",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		block, err := flow.LoadBlock()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading block: " + err.Error()))
+			return
+		}
+		if block == nil {
+			hostsFile, err := loadHostsFile()
+			if err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Error loading context: " + err.Error()))
+				return
+			}
+			block = &flow.Block{HostsFile: hostsFile}
+		}
+
+		if err := block.Lift(); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error unblocking sites: " + err.Error()))
+			return
+		}
+		fmt.Println(ui.SuccessStyle.Render("Sites unblocked"))
+	},
+}
+
+var flowBlockerHostsFileCmd = &cobra.Command{
+	Use:   "hosts-file [path]",
+	Short: "Show or set the hosts file the blocker writes to",
+	Long:  `Show or set the hosts file the blocker writes to. An empty path returns to the system hosts file.`,
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading config: " + err.Error()))
+			return
+		}
+
+		if len(args) == 0 {
+			hostsFile := cfg.Flows.HostsFile
+			if hostsFile == "" {
+				hostsFile = flow.DefaultHostsFile()
+			}
+			fmt.Println(hostsFile)
+			return
+		}
+
+		if active, err := flow.LoadBlock(); err == nil && active != nil {
+			fmt.Println(ui.ErrorStyle.Render("Sites are blocked right now. Run 'taskgo flow blocker lift' first"))
+			return
+		}
+
+		cfg.Flows.HostsFile = args[0]
+		if cfg.Flows.HostsFile != "" {
+			if cfg.Flows.HostsFile, err = filepath.Abs(args[0]); err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Error resolving path: " + err.Error()))
+				return
+			}
+		}
+		if err := config.Save(cfg); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error saving config: " + err.Error()))
+			return
+		}
+		fmt.Println(ui.SuccessStyle.Render("Hosts file updated"))
+	},
+}
+
+var flowBlockerWatchCmd = &cobra.Command{
+	Use:    "watch [pid]",
+	Short:  "Lift the block once a flow run exits",
+	Hidden: true,
+	Args:   cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		pid, err := strconv.Atoi(args[0])
+		if err != nil {
+			return
+		}
+		signal.Ignore(os.Interrupt, syscall.SIGHUP)
+		flow.WatchBlock(pid)
+	},
+}
+
+var flowTeardownCmd = &cobra.Command{
+	Use:   "teardown [name]",
+	Short: "Close what a flow launched and run its teardown commands",
+	Long: `Stop the processes started by the last run of a flow and run the flow's
+teardown commands. Use this when a session ended without tearing down,
+e.g. because the terminal was closed.
+
+Only programs taskgo started itself can be closed: URLs and files handed
+to an already running browser or editor are not tracked.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		m, err := loadFlows()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error initializing flow manager: " + err.Error()))
+			return
+		}
+
+		f, err := m.Get(args[0])
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading flow: " + err.Error()))
+			return
+		}
+		if err := m.CheckTrusted(f.Name); err != nil {
+			fmt.Println(ui.ErrorStyle.Render(capitalize(err.Error())))
+			return
+		}
+
+		sessions, err := flow.LoadSessions()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading flow sessions: " + err.Error()))
+			return
+		}
+		session := sessions[f.Name]
+		if session == nil {
+			session = &flow.Session{Flow: f.Name}
+		}
+
+		teardownFlow(f, session)
+	},
+}
+
+// endFlow offers to tear down a finished flow session. teardown skips the
+// question, keep leaves everything running.
+func endFlow(f *flow.Flow, session *flow.Session, teardown, keep bool) {
+	alive := session.Alive()
+	if len(alive) == 0 && len(f.Teardown) == 0 {
+		flow.RemoveSession(f.Name)
+		return
+	}
+
+	hint := ui.SecondaryStyle.Render(fmt.Sprintf("Run 'taskgo flow teardown %s' to close them later.", f.Name))
+	if keep {
+		fmt.Println(hint)
+		return
+	}
+
+	if !teardown {
+		var parts []string
+		if len(alive) > 0 {
+			parts = append(parts, fmt.Sprintf("close %d launched app(s)", len(alive)))
+		}
+		if len(f.Teardown) > 0 {
+			parts = append(parts, fmt.Sprintf("run %d teardown command(s)", len(f.Teardown)))
+		}
+		question := fmt.Sprintf("Flow ended. %s? [y/N] ", capitalize(strings.Join(parts, " and ")))
+		if key := timer.AskKey(question); key != 'y' && key != 'Y' {
+			fmt.Println(hint)
+			return
+		}
+	}
+
+	teardownFlow(f, session)
+}
+
+// teardownFlow stops the session's running processes, runs the flow's
+// teardown commands and forgets the session.
+func teardownFlow(f *flow.Flow, session *flow.Session) {
+	alive := session.Alive()
+	if len(alive) == 0 && len(f.Teardown) == 0 {
+		fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("Nothing to tear down for flow '%s'", f.Name)))
+		flow.RemoveSession(f.Name)
+		return
+	}
+
+	for _, p := range alive {
+		if err := flow.Terminate(p); err != nil {
+			fmt.Println(ui.ErrorStyle.Render(fmt.Sprintf("Error closing %s (pid %d): %s", p.Resource, p.PID, err.Error())))
+			continue
+		}
+		fmt.Printf("Closed %s (pid %d)\n", p.Resource, p.PID)
+	}
+
+	if len(f.Teardown) > 0 {
+		log, err := flow.OpenLog(f.Name)
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error opening flow log: " + err.Error()))
+			return
+		}
+		defer log.Close()
+
+		if err := f.RunHooks(flow.StageTeardown, log); err != nil {
+			fmt.Println(ui.ErrorStyle.Render(capitalize(err.Error())))
+		}
+	}
+
+	if err := flow.RemoveSession(f.Name); err != nil {
+		fmt.Println(ui.ErrorStyle.Render("Error clearing flow session: " + err.Error()))
+		return
+	}
+	fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("Flow '%s' torn down", f.Name)))
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+var flowTrustCmd = &cobra.Command{
+	Use:   "trust",
+	Short: "Allow the current project's flows to run",
+	Long: `Flows in a project's .taskgo/flows folder can run any command: hooks,
+teardown commands, shell resources and apps. taskgo only runs them after
+you have reviewed the files and trusted the project.
+
+Trust is tied to the contents of the files. When any of them changes, e.g.
+after a pull, the flows stop running until you trust the project again.
+
+Examples:
+  taskgo flow trust
+  taskgo flow trust --revoke`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		m, err := loadFlows()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error initializing flow manager: " + err.Error()))
+			return
+		}
+		if m.ProjectDir == "" {
+			fmt.Println(ui.ErrorStyle.Render(fmt.Sprintf("No project flows here: no %s folder in this directory or above it", flow.ProjectFlowsDir)))
+			return
+		}
+
+		if revoke, _ := cmd.Flags().GetBool("revoke"); revoke {
+			if err := m.Distrust(); err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Error revoking trust: " + err.Error()))
+				return
+			}
+			fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("Flows of %s are no longer trusted", m.ProjectDir)))
+			return
+		}
+
+		if err := m.Trust(); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error trusting project: " + err.Error()))
+			return
+		}
+		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("Flows of %s are trusted:", m.ProjectDir)))
+		for _, file := range m.ProjectFiles() {
+			fmt.Println("  " + file)
+		}
+	},
+}
+
+var flowListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all flows",
+	Run: func(cmd *cobra.Command, args []string) {
+		m, err := loadFlows()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error initializing flow manager: " + err.Error()))
+			return
+		}
+
+		flows := m.List()
+		if len(flows) == 0 {
+			fmt.Println("No flows found. Create one with 'taskgo flow create <name>'")
+			return
+		}
+
+		fmt.Println(ui.RenderTitle("Available Flows"))
+		for _, name := range flows {
+			f, _ := m.Get(name)
+			info := fmt.Sprintf("(%d resources)", len(f.Resources))
+			if m.Source(name) != "" {
+				info = fmt.Sprintf("(%d resources, project)", len(f.Resources))
+				if !m.Trusted() {
+					info = fmt.Sprintf("(%d resources, project, not trusted)", len(f.Resources))
+				}
+			}
+			fmt.Printf("- %s %s\n", name, ui.SecondaryStyle.Render(info))
+		}
+	},
+}
+
+var flowShowCmd = &cobra.Command{
+	Use:   "show [name]",
+	Short: "Show a flow and its resources",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		m, err := loadFlows()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error initializing flow manager: " + err.Error()))
+			return
+		}
+
+		f, err := m.Get(args[0])
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading flow: " + err.Error()))
+			return
+		}
+
+		fmt.Println(ui.RenderTitle(fmt.Sprintf("Flow: %s", f.Name)))
+		if len(f.Resources) == 0 {
+			fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("No resources yet. Add some with 'taskgo flow add %s <resource>'", f.Name)))
+		} else {
+			showResources(f)
+		}
+
+		for _, stage := range []flow.Stage{flow.StagePreRun, flow.StagePostRun, flow.StageTeardown} {
+			commands := *f.Commands(stage)
+			if len(commands) == 0 {
+				continue
+			}
+			fmt.Println()
+			fmt.Println(ui.TreeBranchStyle.Render(capitalize(string(stage))))
+			for i, command := range commands {
+				fmt.Printf("  %d. %s\n", i+1, command)
+			}
+		}
+
+		if len(f.Env) > 0 {
+			fmt.Println()
+			fmt.Println(ui.TreeBranchStyle.Render("Environment"))
+			keys := make([]string, 0, len(f.Env))
+			for k := range f.Env {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				fmt.Printf("  %s=%s\n", k, f.Env[k])
+			}
+		}
+
+		fmt.Println()
+		if f.Group != "" {
+			fmt.Printf("Group:             %s\n", f.Group)
+		}
+		fmt.Printf("Session:           %s\n", f.SessionPlan())
+		if f.Browser != "" {
+			fmt.Printf("Browser:           %s\n", f.Browser)
+		}
+		if len(f.Block) > 0 {
+			fmt.Printf("Blocks (Zen):      %s\n", strings.Join(f.Block, ", "))
+		}
+		if f.WorkDir != "" {
+			fmt.Printf("Working directory: %s\n", f.WorkDir)
+		}
+		policy := f.OnFailure
+		if policy == "" {
+			policy = flow.FailureAbort
+		}
+		fmt.Printf("On hook failure:   %s\n", policy)
+		if path, err := flow.LogPath(f.Name); err == nil {
+			fmt.Printf("Log:               %s\n", path)
+		}
+		if source := m.Source(f.Name); source != "" {
+			fmt.Printf("Defined in:        %s\n", source)
+			if !m.Trusted() {
+				fmt.Println(ui.WarningStyle.Render("The project's flows are not trusted. Review them and run 'taskgo flow trust' to run this flow."))
+			}
+		}
+	},
+}
+
+// showResources prints a flow's resources as a table.
+func showResources(f *flow.Flow) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"#", "Type", "Resource", "Arguments"})
+	table.SetBorder(true)
+	table.SetCenterSeparator("|")
+	table.SetColumnSeparator("|")
+	table.SetRowSeparator("-")
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	for i, res := range f.Resources {
+		table.Append([]string{strconv.Itoa(i + 1), string(res.Type), res.Target, strings.Join(res.Args, " ")})
+	}
+	table.Render()
+}
+
+var flowSetCmd = &cobra.Command{
+	Use:   "set [name]",
+	Short: "Set a flow's group, session plan, environment and hook policy",
+	Long: `Configure how a flow runs.
+
+--group binds the flow to a task group: it is checked out while the flow
+runs, its open tasks are shown on the timer screen and the previous group
+is checked out again afterwards. --group "" removes the binding.
+
+--browser opens the flow's URLs in one new window of that browser, also
+outside Zen Mode. See 'taskgo flow browser list'.
+
+--block and --unblock add and remove domains blocked while the flow runs
+in Zen Mode. See 'taskgo flow blocker --help'.
+
+--work, --break and --duration set the session plan: alternating work and
+break timers until the duration is over. Without --work the session is a
+single block. Flows without a plan run for timer.flow of the config
+file (4h unless changed).
+
+Environment variables and the working directory apply to pre-run, post-run
+and teardown hooks and to launched resources. A terminal resource keeps its
+own directory. --env KEY= removes a variable.
+
+With --on-failure abort (the default) a failing pre-run hook stops the run
+before any resource is opened and a failing post-run hook skips the rest.
+With continue every hook runs and failures are only reported. Teardown
+commands always all run. Hook output is appended to the flow's log, see
+'taskgo flow show'.
+
+Examples:
+  taskgo flow set coding --group work --work 50m --break 10m --duration 3h
+  taskgo flow set coding --workdir ~/src/taskgo
+  taskgo flow set coding --env NODE_ENV=development --env PORT=3000
+  taskgo flow set coding --on-failure continue`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		m, err := loadFlows()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error initializing flow manager: " + err.Error()))
+			return
+		}
+		if _, err := m.Get(name); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading flow: " + err.Error()))
+			return
+		}
+
+		changed := false
+		if cmd.Flags().Changed("workdir") {
+			dir, _ := cmd.Flags().GetString("workdir")
+			if err := m.SetWorkDir(name, dir); err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Error setting working directory: " + err.Error()))
+				return
+			}
+			changed = true
+		}
+
+		env, _ := cmd.Flags().GetStringArray("env")
+		for _, pair := range env {
+			key, value, ok := strings.Cut(pair, "=")
+			if !ok || key == "" {
+				fmt.Println(ui.ErrorStyle.Render(fmt.Sprintf("Invalid environment variable '%s'. Use KEY=VALUE", pair)))
+				return
+			}
+			if err := m.SetEnv(name, key, value); err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Error setting environment: " + err.Error()))
+				return
+			}
+			changed = true
+		}
+
+		if cmd.Flags().Changed("group") {
+			group, _ := cmd.Flags().GetString("group")
+			if err := m.SetGroup(name, group); err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Error setting group: " + err.Error()))
+				return
+			}
+			changed = true
+		}
+
+		if cmd.Flags().Changed("work") || cmd.Flags().Changed("break") || cmd.Flags().Changed("duration") {
+			f, _ := m.Get(name)
+			plan := f.SessionPlan()
+			if cmd.Flags().Changed("work") {
+				plan.Work, _ = cmd.Flags().GetDuration("work")
+			}
+			if cmd.Flags().Changed("break") {
+				plan.Break, _ = cmd.Flags().GetDuration("break")
+			}
+			if cmd.Flags().Changed("duration") {
+				plan.Total, _ = cmd.Flags().GetDuration("duration")
+			}
+			if err := m.SetPlan(name, plan); err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Error setting session plan: " + err.Error()))
+				return
+			}
+			changed = true
+		}
+
+		if cmd.Flags().Changed("block") || cmd.Flags().Changed("unblock") {
+			f, _ := m.Get(name)
+			domains := slices.Clone(f.Block)
+			add, _ := cmd.Flags().GetStringArray("block")
+			remove, _ := cmd.Flags().GetStringArray("unblock")
+			for _, value := range add {
+				domain, err := flow.NormalizeDomain(value)
+				if err != nil {
+					fmt.Println(ui.ErrorStyle.Render(err.Error()))
+					return
+				}
+				if !slices.Contains(domains, domain) {
+					domains = append(domains, domain)
+				}
+			}
+			for _, value := range remove {
+				domain, err := flow.NormalizeDomain(value)
+				if err != nil {
+					fmt.Println(ui.ErrorStyle.Render(err.Error()))
+					return
+				}
+				domains = slices.DeleteFunc(domains, func(d string) bool { return d == domain })
+			}
+			if err := m.SetBlocked(name, domains); err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Error setting blocked sites: " + err.Error()))
+				return
+			}
+			changed = true
+		}
+
+		if cmd.Flags().Changed("browser") {
+			browser, _ := cmd.Flags().GetString("browser")
+			if err := m.SetBrowser(name, browser); err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Error setting browser: " + err.Error()))
+				return
+			}
+			changed = true
+		}
+
+		if cmd.Flags().Changed("on-failure") {
+			policy, _ := cmd.Flags().GetString("on-failure")
+			if err := m.SetFailurePolicy(name, policy); err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Error setting failure policy: " + err.Error()))
+				return
+			}
+			changed = true
+		}
+
+		if !changed {
+			fmt.Println(ui.WarningStyle.Render("Nothing to set. See 'taskgo flow set --help'"))
+			return
+		}
+		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("Flow '%s' updated", name)))
+	},
+}
+
+var flowRemoveResourceCmd = &cobra.Command{
+	Use:   "remove-resource [name] [resource|position]",
+	Short: "Remove a resource or hook from a flow",
+	Long: `Remove a resource from a flow, given by its value or by its position as
+shown by 'taskgo flow show'. With --pre, --post or --teardown a hook
+command is removed instead.
+
+Examples:
+  taskgo flow remove-resource coding 2
+  taskgo flow remove-resource coding https://github.com
+  taskgo flow remove-resource coding 1 --pre`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		stage, isCommand, err := stageFlag(cmd)
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render(err.Error()))
+			return
+		}
+
+		m, err := loadFlows()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error initializing flow manager: " + err.Error()))
+			return
+		}
+
+		if isCommand {
+			removed, err := m.RemoveCommand(args[0], stage, args[1])
+			if err != nil {
+				fmt.Println(ui.ErrorStyle.Render(fmt.Sprintf("Error removing %s command: %s", stage, err.Error())))
+				return
+			}
+			fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("Removed %s command '%s' from flow '%s'", stage, removed, args[0])))
+			return
+		}
+
+		removed, err := m.RemoveResource(args[0], args[1])
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error removing resource: " + err.Error()))
+			return
+		}
+		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("Removed '%s' from flow '%s'", removed, args[0])))
+	},
+}
+
+var flowReorderCmd = &cobra.Command{
+	Use:   "reorder [name] [resource|position] [new-position]",
+	Short: "Move a resource to another position in a flow",
+	Long: `Resources are opened in order. Move one to a new position.
+
+Examples:
+  taskgo flow reorder coding 3 1    # open the third resource first`,
+	Args: cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		to, err := strconv.Atoi(args[2])
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render(fmt.Sprintf("Invalid position '%s'", args[2])))
+			return
+		}
+
+		m, err := loadFlows()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error initializing flow manager: " + err.Error()))
+			return
+		}
+
+		if err := m.MoveResource(args[0], args[1], to); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error reordering resources: " + err.Error()))
+			return
+		}
+		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("Resource moved to position %d", to)))
+	},
+}
+
+var flowRenameCmd = &cobra.Command{
+	Use:   "rename [name] [new-name]",
+	Short: "Rename a flow",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		m, err := loadFlows()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error initializing flow manager: " + err.Error()))
+			return
+		}
+
+		if err := m.Rename(args[0], args[1]); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error renaming flow: " + err.Error()))
+			return
+		}
+		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("Flow '%s' renamed to '%s'", args[0], args[1])))
+	},
+}
+
+var flowCloneCmd = &cobra.Command{
+	Use:   "clone [name] [new-name]",
+	Short: "Copy a flow under a new name",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		m, err := loadFlows()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error initializing flow manager: " + err.Error()))
+			return
+		}
+
+		if err := m.Clone(args[0], args[1]); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error cloning flow: " + err.Error()))
+			return
+		}
+		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("Flow '%s' cloned to '%s'", args[0], args[1])))
+	},
+}
+
+var flowDeleteCmd = &cobra.Command{
+	Use:   "delete [name]",
+	Short: "Delete a flow",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		yes, _ := cmd.Flags().GetBool("yes")
+		name := args[0]
+
+		m, err := loadFlows()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error initializing flow manager: " + err.Error()))
+			return
+		}
+
+		f, err := m.Get(name)
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error deleting flow: " + err.Error()))
+			return
+		}
+
+		if !yes && !promptYesNo(fmt.Sprintf("Delete flow '%s' with %d resources?", name, len(f.Resources)), false) {
+			fmt.Println(ui.WarningStyle.Render("Aborted."))
+			return
+		}
+
+		if err := m.Delete(name); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error deleting flow: " + err.Error()))
+			return
+		}
+		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("Flow '%s' deleted", name)))
+	},
+}
+
+// openInBrowser opens urls in one new window of the flow's browser, or of
+// the configured or system default browser. zen adds its kiosk flags.
+func openInBrowser(f *flow.Flow, urls []string, zen bool) (flow.Process, error) {
+	settings := config.Current()
+	name := f.Browser
+	if name == "" {
+		name = settings.Flows.Browser
+	}
+	b, err := flow.ResolveBrowser(name, settings.Flows.Browsers)
+	if err != nil {
+		return flow.Process{}, err
+	}
+
+	browserCmd, err := flow.BrowserCommand(b, urls, zen)
+	if err != nil {
+		return flow.Process{}, err
+	}
+	browserCmd.Env = f.Environ()
+
+	fmt.Printf("Opening %d URLs in %s...\n", len(urls), filepath.Base(b.Binary))
+	return flow.Start(browserCmd, b.Binary)
+}
+
+// openResources launches a flow's resources with its environment and
+// working directory and returns the processes it started.
+func openResources(f *flow.Flow, zen bool) []flow.Process {
+	resources := f.Resources
+	if len(resources) == 0 {
+		return nil
+	}
+
+	var procs []flow.Process
+
+	// URLs are collected so Zen Mode can open them in a single window
+	var urls []string
+	for _, res := range resources {
+		if res.Type == flow.TypeURL {
+			urls = append(urls, res.Target)
+			continue
+		}
+
+		fmt.Printf("Opening %s: %s...\n", res.Type, res)
+		p, err := f.Launch(res)
+		if err != nil {
+			fmt.Printf("Error opening %s: %v\n", res, err)
+			continue
+		}
+		procs = append(procs, p)
+	}
+
+	if len(urls) == 0 {
+		return procs
+	}
+
+	if zen || f.Browser != "" {
+		p, err := openInBrowser(f, urls, zen)
+		if err == nil {
+			return append(procs, p)
+		}
+		fmt.Printf("Error opening browser: %v\n", err)
+		fmt.Println("Falling back to the default URL handler.")
+	}
+
+	// Normal mode or fallback
+	for _, url := range urls {
+		fmt.Printf("Opening %s...\n", url)
+		res := flow.Resource{Type: flow.TypeURL, Target: url}
+		p, err := f.Launch(res)
 		if err != nil {
 			fmt.Printf("Error opening %s: %v\n", url, err)
+			continue
 		}
+		procs = append(procs, p)
 	}
+	return procs
 }
 
 func init() {
@@ -235,6 +1747,63 @@ func init() {
 	flowCmd.AddCommand(flowAddCmd)
 	flowCmd.AddCommand(flowRunCmd)
 	flowCmd.AddCommand(flowListCmd)
+	flowCmd.AddCommand(flowShowCmd)
+	flowCmd.AddCommand(flowRemoveResourceCmd)
+	flowCmd.AddCommand(flowReorderCmd)
+	flowCmd.AddCommand(flowRenameCmd)
+	flowCmd.AddCommand(flowCloneCmd)
+	flowCmd.AddCommand(flowDeleteCmd)
+	flowCmd.AddCommand(flowTeardownCmd)
+	flowCmd.AddCommand(flowSetCmd)
+	flowCmd.AddCommand(flowStatsCmd)
+	flowCmd.AddCommand(flowExportCmd)
+	flowCmd.AddCommand(flowImportCmd)
+	flowCmd.AddCommand(flowTrustCmd)
+	flowCmd.AddCommand(flowBrowserCmd)
+	flowCmd.AddCommand(flowBlockerCmd)
+	flowBlockerCmd.AddCommand(flowBlockerStatusCmd)
+	flowBlockerCmd.AddCommand(flowBlockerLiftCmd)
+	flowBlockerCmd.AddCommand(flowBlockerHostsFileCmd)
+	flowBlockerCmd.AddCommand(flowBlockerWatchCmd)
+	flowBrowserCmd.AddCommand(flowBrowserListCmd)
+	flowBrowserCmd.AddCommand(flowBrowserSetCmd)
+	flowBrowserCmd.AddCommand(flowBrowserRemoveCmd)
+	flowBrowserCmd.AddCommand(flowBrowserDefaultCmd)
 
 	flowRunCmd.Flags().BoolVarP(&zenMode, "zen", "z", false, "Run in Zen Mode (Kiosk Mode)")
+	flowRunCmd.Flags().Bool("teardown", false, "Close launched apps and run teardown commands without asking when the session ends")
+	flowRunCmd.Flags().Bool("keep", false, "Leave launched apps running when the session ends")
+	flowAddCmd.Flags().Bool("pre", false, "Add the arguments as shell commands run before the resources are opened")
+	flowAddCmd.Flags().Bool("post", false, "Add the arguments as shell commands run after the session ends")
+	flowAddCmd.Flags().Bool("teardown", false, "Add the arguments as shell commands run when the flow is torn down")
+	flowRemoveResourceCmd.Flags().Bool("pre", false, "Remove a pre-run hook instead of a resource")
+	flowRemoveResourceCmd.Flags().Bool("post", false, "Remove a post-run hook instead of a resource")
+	flowRemoveResourceCmd.Flags().Bool("teardown", false, "Remove a teardown command instead of a resource")
+	flowSetCmd.Flags().String("workdir", "", "Directory hooks and resources start in (empty to clear)")
+	flowSetCmd.Flags().StringArray("env", nil, "Set an environment variable as KEY=VALUE (KEY= removes it)")
+	flowBrowserSetCmd.Flags().String("binary", "", "Program to run")
+	flowBrowserSetCmd.Flags().StringArray("args", nil, "Arguments always passed first")
+	flowBrowserSetCmd.Flags().StringArray("new-window", nil, "Flags opening a new window")
+	flowBrowserSetCmd.Flags().StringArray("kiosk", nil, "Flags added in Zen Mode, e.g. --kiosk or --start-fullscreen")
+	flowBrowserSetCmd.Flags().String("profile", "", "Profile directory to run the browser with")
+	flowBrowserSetCmd.Flags().StringArray("profile-args", nil, "Flags selecting the profile, with {profile} for the directory")
+	flowExportCmd.Flags().StringP("output", "o", "", "Write to this file instead of stdout")
+	flowExportCmd.Flags().String("project-dir", "", "Directory written as {{.ProjectDir}} (default: the current project)")
+	flowImportCmd.Flags().Bool("force", false, "Replace flows that already exist")
+	flowImportCmd.Flags().String("project-dir", "", "Directory {{.ProjectDir}} expands to")
+	flowTrustCmd.Flags().Bool("revoke", false, "Stop trusting the project's flows")
+	flowStatsCmd.Flags().Int("days", 7, "Number of days to show")
+	flowStatsCmd.Flags().Int("weeks", 0, "Show this many weeks instead of days")
+	flowSetCmd.Flags().StringArray("block", nil, "Block a domain while the flow runs in Zen Mode")
+	flowSetCmd.Flags().StringArray("unblock", nil, "Stop blocking a domain")
+	flowRunCmd.Flags().Bool("no-block", false, "Don't block the flow's distracting sites this time")
+	flowSetCmd.Flags().String("browser", "", "Browser to open URLs in, see 'taskgo flow browser list' (empty for the default)")
+	flowSetCmd.Flags().String("group", "", "Task group checked out while the flow runs")
+	flowSetCmd.Flags().Duration("work", 0, "Length of a work interval (0 for a single block)")
+	flowSetCmd.Flags().Duration("break", 0, "Length of the break between work intervals")
+	flowSetCmd.Flags().Duration("duration", 0, "Total session length (0 for the default 4h)")
+	flowRunCmd.Flags().Duration("duration", 0, "Session length for this run, overriding the flow's plan")
+	flowSetCmd.Flags().String("on-failure", "", "What a failing hook does: abort or continue")
+	flowDeleteCmd.Flags().BoolP("yes", "y", false, "Delete without asking for confirmation")
+	flowAddCmd.Flags().StringP("type", "t", "", "Resource type: url, app, file, dir, shell, terminal (guessed if omitted)")
 }
@@ -35,21 +35,21 @@ var groupCmd = &cobra.Command{
 				validity, _ = cmd.Flags().GetString("validity")
 			}
 
-			// Save to context if validity is provided
+			// Save to the config if validity is provided
 			if validity != "" {
-				ctx, err := config.LoadContext()
+				cfg, err := config.Load()
 				if err != nil {
-					fmt.Println(ui.ErrorStyle.Render("Error loading context: " + err.Error()))
+					fmt.Println(ui.ErrorStyle.Render("Error loading config: " + err.Error()))
 					return
 				}
 
-				if ctx.GroupValidity == nil {
-					ctx.GroupValidity = make(map[string]string)
+				if cfg.Defaults.GroupValidity == nil {
+					cfg.Defaults.GroupValidity = make(map[string]string)
 				}
-				ctx.GroupValidity[groupName] = validity
+				cfg.Defaults.GroupValidity[groupName] = validity
 
-				if err := config.SaveContext(ctx); err != nil {
-					fmt.Println(ui.ErrorStyle.Render("Error saving context: " + err.Error()))
+				if err := config.Save(cfg); err != nil {
+					fmt.Println(ui.ErrorStyle.Render("Error saving config: " + err.Error()))
 					return
 				}
 				fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("Group '%s' validity set to %s.", groupName, validity)))
@@ -86,6 +86,9 @@ var groupListCmd = &cobra.Command{
 
 		groups := make(map[string]bool)
 		for _, t := range tasks {
+			if t.ArchivedAt != nil {
+				continue
+			}
 			groupName := t.Group
 			if groupName == "" {
 				groupName = "General"
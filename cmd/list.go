@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/MohakGupta2004/taskgo/internal/config"
 	"github.com/MohakGupta2004/taskgo/internal/task"
 	"github.com/MohakGupta2004/taskgo/internal/ui"
 	"github.com/charmbracelet/lipgloss"
@@ -13,10 +15,125 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// displayTimeFormat is used for every timestamp shown to the user.
+const displayTimeFormat = "02 Jan 06 15:04 MST"
+
+// listColumn describes a column that can be shown in the task table.
+type listColumn struct {
+	Header string
+	Value  func(t task.Task) string
+}
+
+var listColumns = map[string]listColumn{
+	"id":    {Header: "ID", Value: func(t task.Task) string { return strconv.Itoa(t.ID) }},
+	"uuid":  {Header: "UUID", Value: func(t task.Task) string { return t.UUID }},
+	"title": {Header: "Title", Value: func(t task.Task) string { return t.Title }},
+	"group": {Header: "Group", Value: func(t task.Task) string {
+		if t.Group == "" {
+			return "General"
+		}
+		return t.Group
+	}},
+	"status":  {Header: "Status", Value: func(t task.Task) string { return renderStatus(t.Status) }},
+	"tags":    {Header: "Tags", Value: func(t task.Task) string { return strings.Join(t.Tags, ", ") }},
+	"created": {Header: "Created At", Value: func(t task.Task) string { return t.CreatedAt.Format(displayTimeFormat) }},
+	"completed": {Header: "Completed At", Value: func(t task.Task) string {
+		if t.CompletedAt == nil {
+			return ""
+		}
+		return t.CompletedAt.Format(displayTimeFormat)
+	}},
+	"due": {Header: "Due", Value: func(t task.Task) string {
+		if t.Due == nil {
+			return ""
+		}
+		due := t.Due.Format(displayTimeFormat)
+		if task.IsOverdue(t, time.Now()) {
+			return ui.ErrorStyle.Render(due)
+		}
+		return due
+	}},
+	"valid": {Header: "Valid For", Value: func(t task.Task) string {
+		if t.ValidUntil == nil {
+			return ""
+		}
+		remaining := time.Until(*t.ValidUntil).Round(time.Minute)
+		if remaining > 0 {
+			return remaining.String()
+		}
+		return "Expired"
+	}},
+}
+
+var defaultListColumns = []string{"id", "title", "status", "created", "completed", "valid"}
+
+// minTitleWidth keeps titles readable on narrow terminals.
+const minTitleWidth = 20
+
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all tasks",
+	Long: `List tasks grouped by group.
+
+Use --sort to order tasks by one or more fields (prefix with '-' or add ':desc'
+for descending order) and --columns to choose which fields are shown. Pass
+--save to store the given --sort and --columns as your defaults.
+Archived tasks are hidden; use --archived to list only those.
+
+Columns: id, uuid, title, group, status, tags, created, completed, due, valid
+Sort fields: id, uuid, title, group, status, created, completed, due, valid
+
+Examples:
+  taskgo list --sort status,-created
+  taskgo list --columns id,title,tags --save`,
 	Run: func(cmd *cobra.Command, args []string) {
+		sortFlag, _ := cmd.Flags().GetString("sort")
+		columnsFlag, _ := cmd.Flags().GetStringSlice("columns")
+		save, _ := cmd.Flags().GetBool("save")
+		archived, _ := cmd.Flags().GetBool("archived")
+
+		cfg := config.Current()
+		if save {
+			var err error
+			if cfg, err = config.Load(); err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Error loading config: " + err.Error()))
+				return
+			}
+			if cmd.Flags().Changed("sort") {
+				cfg.Defaults.ListSort = sortFlag
+			}
+			if cmd.Flags().Changed("columns") {
+				cfg.Defaults.ListColumns = columnsFlag
+			}
+		}
+
+		if !cmd.Flags().Changed("sort") {
+			sortFlag = cfg.Defaults.ListSort
+		}
+		if !cmd.Flags().Changed("columns") {
+			columnsFlag = cfg.Defaults.ListColumns
+		}
+
+		sortKeys, err := task.ParseSort(sortFlag)
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render(err.Error()))
+			return
+		}
+
+		columns, err := parseColumns(columnsFlag)
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render(err.Error()))
+			return
+		}
+
+		if save {
+			if err := config.Save(cfg); err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Error saving config: " + err.Error()))
+				return
+			}
+			fmt.Println(ui.SuccessStyle.Render("List defaults saved."))
+		}
+
 		fmt.Println(ui.RenderBanner())
 		tasks, err := taskManager.List()
 		if err != nil {
@@ -24,109 +141,168 @@ var listCmd = &cobra.Command{
 			return
 		}
 
+		var shown []task.Task
+		for _, t := range tasks {
+			if (t.ArchivedAt != nil) == archived {
+				shown = append(shown, t)
+			}
+		}
+		tasks = shown
+
 		if len(tasks) == 0 {
 			fmt.Println(ui.WarningStyle.Render("No tasks found."))
 			return
 		}
 
-		// Group tasks
-		groupedTasks := make(map[string][]task.Task)
-		var groups []string
-		for _, t := range tasks {
-			groupName := t.Group
-			if groupName == "" {
-				groupName = "General"
-			}
-			if _, exists := groupedTasks[groupName]; !exists {
-				groups = append(groups, groupName)
-			}
-			groupedTasks[groupName] = append(groupedTasks[groupName], t)
-		}
-
-		// Iterate over groups
-		for _, group := range groups {
-			// Render Tree Branch / Group Header
-			fmt.Println(ui.TreeBranchStyle.Render("├── " + group))
-
-			table := tablewriter.NewWriter(os.Stdout)
-			table.SetHeader([]string{"ID", "Title", "Status", "Created At", "Completed At"})
-			table.SetBorder(true)
-			table.SetHeaderLine(true)
-			table.SetRowLine(false)
-			table.SetCenterSeparator("|")
-			table.SetColumnSeparator("|")
-			table.SetRowSeparator("-")
-			table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
-			table.SetAlignment(tablewriter.ALIGN_LEFT)
-			table.SetAutoWrapText(false)
-			table.SetReflowDuringAutoWrap(false)
-
-			for _, t := range groupedTasks[group] {
-				statusStr := string(t.Status)
-				if t.Status == task.StatusTodo {
-					statusStr = "pending"
-				}
+		task.SortTasks(tasks, sortKeys)
+		renderTaskTables(tasks, columns, "group")
+	},
+}
 
-				titleStr := t.Title
-
-				// Apply styling based on status
-				switch t.Status {
-				case task.StatusTodo:
-					statusStr = ui.StatusTodoStyle.Render(statusStr)
-					titleStr = ui.PendingRowStyle.Render(titleStr)
-				case task.StatusInProgress:
-					statusStr = ui.StatusInProgressStyle.Render(statusStr)
-					titleStr = ui.InProgressRowStyle.Render(titleStr)
-				case task.StatusCompleted:
-					statusStr = ui.StatusCompletedStyle.Render(statusStr)
-					titleStr = ui.CompletedRowStyle.Render(titleStr)
-				}
+// parseColumns validates column names, returning the defaults when none
+// are given.
+func parseColumns(names []string) ([]string, error) {
+	if len(names) == 0 {
+		return defaultListColumns, nil
+	}
 
-				// Wrap title if it's too long
-				titleStr = lipgloss.NewStyle().Width(40).Render(titleStr)
+	var columns []string
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := listColumns[name]; !ok {
+			return nil, fmt.Errorf("unknown column '%s'. Use: id, uuid, title, group, status, tags, created, completed, due, valid", name)
+		}
+		columns = append(columns, name)
+	}
+	return columns, nil
+}
 
-				completedAt := ""
-				if t.CompletedAt != nil {
-					completedAt = t.CompletedAt.Format("02 Jan 06 15:04 MST")
-				}
+// groupByOptions are the ways tasks can be split into separate tables.
+var groupByOptions = []string{"group", "status", "none"}
 
-				validUntil := ""
-				if t.ValidUntil != nil {
-					remaining := time.Until(*t.ValidUntil).Round(time.Minute)
-					if remaining > 0 {
-						validUntil = remaining.String()
-					} else {
-						validUntil = "Expired"
-					}
-				}
+// renderTaskTables prints one table per group or status, keeping the order
+// in which they first appear in tasks. groupBy "none" prints a single table.
+func renderTaskTables(tasks []task.Task, columns []string, groupBy string) {
+	if groupBy == "none" {
+		renderTaskTable(tasks, columns)
+		return
+	}
 
-				// Apply orange color to all columns for pending tasks
-				row := []string{
-					strconv.Itoa(t.ID),
-					titleStr,
-					statusStr,
-					t.CreatedAt.Format("02 Jan 06 15:04 MST"),
-					completedAt,
-					validUntil,
-				}
+	groupedTasks := make(map[string][]task.Task)
+	var groups []string
+	for _, t := range tasks {
+		groupName := t.Group
+		if groupBy == "status" {
+			groupName = string(t.Status)
+		} else if groupName == "" {
+			groupName = "General"
+		}
+		if _, exists := groupedTasks[groupName]; !exists {
+			groups = append(groups, groupName)
+		}
+		groupedTasks[groupName] = append(groupedTasks[groupName], t)
+	}
 
-				if t.Status == task.StatusTodo {
-					for i, cell := range row {
-						// Title and Status are already styled
-						if i != 1 && i != 2 {
-							row[i] = lipgloss.NewStyle().Foreground(ui.OrangeColor).Render(cell)
-						}
-					}
-				}
+	for _, group := range groups {
+		// Render Tree Branch / Group Header
+		fmt.Println(ui.TreeBranchStyle.Render("├── " + group))
+		renderTaskTable(groupedTasks[group], columns)
+		fmt.Println("│") // Spacer between groups
+	}
+}
 
-				table.Append(row)
+// renderTaskTable prints tasks as a table sized to the terminal. The title
+// column takes whatever width the other columns leave free.
+func renderTaskTable(tasks []task.Task, columns []string) {
+	headers := make([]string, len(columns))
+	rows := make([][]string, len(tasks))
+	for i := range rows {
+		rows[i] = make([]string, len(columns))
+	}
+
+	// Each cell is padded by a space on both sides and followed by a separator.
+	used := 1
+	titleCol := -1
+	for c, name := range columns {
+		col := listColumns[name]
+		headers[c] = col.Header
+		width := lipgloss.Width(col.Header)
+		for i, t := range tasks {
+			rows[i][c] = col.Value(t)
+			width = max(width, lipgloss.Width(rows[i][c]))
+		}
+		if name == "title" {
+			titleCol = c
+			continue
+		}
+		used += width + 3
+	}
+
+	titleWidth := max(ui.TerminalWidth()-used-3, minTitleWidth)
+
+	for i, t := range tasks {
+		if titleCol >= 0 {
+			// Wrap title if it's too long
+			rows[i][titleCol] = lipgloss.NewStyle().Width(titleWidth).Render(renderTitle(t))
+		}
+
+		// Apply orange color to all columns for pending tasks
+		if t.Status == task.StatusTodo {
+			for c, name := range columns {
+				// Title and Status are already styled
+				if name != "title" && name != "status" {
+					rows[i][c] = lipgloss.NewStyle().Foreground(ui.OrangeColor).Render(rows[i][c])
+				}
 			}
-			table.Render()
-			fmt.Println("│") // Spacer between groups
 		}
-	},
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader(headers)
+	table.SetBorder(true)
+	table.SetHeaderLine(true)
+	table.SetRowLine(false)
+	table.SetCenterSeparator("|")
+	table.SetColumnSeparator("|")
+	table.SetRowSeparator("-")
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAutoWrapText(false)
+	table.SetReflowDuringAutoWrap(false)
+	table.AppendBulk(rows)
+	table.Render()
+}
+
+// renderStatus returns the styled status label of a task.
+func renderStatus(status task.TaskStatus) string {
+	switch status {
+	case task.StatusTodo:
+		return ui.StatusTodoStyle.Render("pending")
+	case task.StatusInProgress:
+		return ui.StatusInProgressStyle.Render(string(status))
+	case task.StatusCompleted:
+		return ui.StatusCompletedStyle.Render(string(status))
+	}
+	return string(status)
+}
+
+// renderTitle returns the task title styled by its status.
+func renderTitle(t task.Task) string {
+	switch t.Status {
+	case task.StatusTodo:
+		return ui.PendingRowStyle.Render(t.Title)
+	case task.StatusInProgress:
+		return ui.InProgressRowStyle.Render(t.Title)
+	case task.StatusCompleted:
+		return ui.CompletedRowStyle.Render(t.Title)
+	}
+	return t.Title
 }
 
 func init() {
+	listCmd.Flags().StringP("sort", "s", "", "Sort by fields, e.g. status,-created or group:asc,created:desc")
+	listCmd.Flags().StringSliceP("columns", "c", nil, "Columns to show, e.g. id,title,status")
+	listCmd.Flags().Bool("save", false, "Save --sort and --columns as defaults")
+	listCmd.Flags().Bool("archived", false, "List archived tasks instead")
 	rootCmd.AddCommand(listCmd)
 }
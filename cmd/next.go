@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/MohakGupta2004/taskgo/internal/config"
+	"github.com/MohakGupta2004/taskgo/internal/task"
+	"github.com/MohakGupta2004/taskgo/internal/timer"
+	"github.com/MohakGupta2004/taskgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var nextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Show the single most important task to work on now",
+	Long: `Pick the one open task to focus on, ranked by urgency: overdue and upcoming
+due dates, work already in progress, tasks that unblock others and the
+checked out group. Tasks waiting on unfinished dependencies are skipped.
+
+Use --start to mark it in progress and immediately start a pomodoro on it.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		start, _ := cmd.Flags().GetBool("start")
+		duration, _ := cmd.Flags().GetDuration("duration")
+		if !cmd.Flags().Changed("duration") {
+			duration = time.Duration(config.Current().Timer.Pomodoro)
+		}
+
+		tasks, err := taskManager.List()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading tasks: " + err.Error()))
+			return
+		}
+
+		currentGroup := ""
+		if ctx, err := config.LoadContext(); err == nil {
+			currentGroup = ctx.CurrentGroup
+		}
+
+		now := time.Now()
+		next, score, ok := task.Next(tasks, currentGroup, now)
+		if !ok {
+			fmt.Println(ui.SuccessStyle.Render("Nothing to do. Enjoy your free time! 🎉"))
+			return
+		}
+
+		deps := task.NewDependencies(tasks)
+		fmt.Println(renderTaskDetails(next, deps))
+		fmt.Println()
+		fmt.Println(ui.SecondaryStyle.Render(fmt.Sprintf("Urgency: %.1f", score)))
+
+		if !start {
+			return
+		}
+
+		if next.Status != task.StatusInProgress {
+			if err := taskManager.Update(next.ID, task.StatusInProgress); err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Error starting task: " + err.Error()))
+				return
+			}
+		}
+
+		runLoggedTimer(duration, fmt.Sprintf("#%d %s", next.ID, next.Title), timer.KindPomodoro, next.UUID)
+	},
+}
+
+func init() {
+	nextCmd.Flags().Bool("start", false, "Mark the task in progress and start a pomodoro")
+	nextCmd.Flags().Duration("duration", 0, "Pomodoro length used with --start (default: timer.pomodoro)")
+	rootCmd.AddCommand(nextCmd)
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MohakGupta2004/taskgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var noteCmd = &cobra.Command{
+	Use:   "note [id|uuid] [text]",
+	Short: "Add a timestamped annotation to a task",
+	Long: `Append a timestamped annotation to a task. Annotations cannot be edited;
+use 'taskgo edit <id>' to change the task's notes instead.
+
+Examples:
+  taskgo note 1 Waiting for review from the platform team
+  taskgo note 1 "Deployed to **staging**"`,
+	Args: cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		target, err := taskManager.Resolve(args[0])
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error finding task: " + err.Error()))
+			return
+		}
+		id := target.ID
+
+		text := strings.TrimSpace(strings.Join(args[1:], " "))
+		if text == "" {
+			fmt.Println(ui.ErrorStyle.Render("Annotation text must not be empty"))
+			return
+		}
+
+		if err := taskManager.Annotate(id, text); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error annotating task: " + err.Error()))
+			return
+		}
+		fmt.Println(ui.SuccessStyle.Render("Annotation added!"))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(noteCmd)
+}
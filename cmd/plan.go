@@ -0,0 +1,433 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MohakGupta2004/taskgo/internal/config"
+	"github.com/MohakGupta2004/taskgo/internal/plan"
+	"github.com/MohakGupta2004/taskgo/internal/task"
+	"github.com/MohakGupta2004/taskgo/internal/timer"
+	"github.com/MohakGupta2004/taskgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// timelineSlot is the resolution of the plan timeline.
+const timelineSlot = 30 * time.Minute
+
+var planCmd = &cobra.Command{
+	Use:   "plan [day]",
+	Short: "Plan a day in time blocks",
+	Long: `Assign tasks to time blocks with an estimate and see the day as a
+timeline. Overlapping blocks, blocks outside your working hours and days
+with more planned than working time are flagged.
+
+The day defaults to today and accepts today, tomorrow, a weekday or a date
+(YYYY-MM-DD).
+
+Examples:
+  taskgo plan today
+  taskgo plan add 4 --at 09:30 --for 90m
+  taskgo plan add 7 --for 45m               # after the last block
+  taskgo plan add 2 --day tomorrow --at 14:00 --for 1h
+  taskgo plan remove 2
+  taskgo plan hours 08:30-16:30
+  taskgo plan start                         # pomodoro for the current block
+  taskgo plan start --wait                  # wait for the next block to start`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		value := "today"
+		if len(args) == 1 {
+			value = args[0]
+		}
+		day, err := parsePlanDay(value)
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render(err.Error()))
+			return
+		}
+		showPlan(day)
+	},
+}
+
+var planAddCmd = &cobra.Command{
+	Use:   "add [id|uuid]",
+	Short: "Add a time block for a task",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dayFlag, _ := cmd.Flags().GetString("day")
+		at, _ := cmd.Flags().GetString("at")
+		duration, _ := cmd.Flags().GetDuration("for")
+		if !cmd.Flags().Changed("for") {
+			duration = time.Duration(config.Current().Timer.Pomodoro)
+		}
+
+		if duration <= 0 {
+			fmt.Println(ui.ErrorStyle.Render("--for must be positive"))
+			return
+		}
+
+		day, err := parsePlanDay(dayFlag)
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render(err.Error()))
+			return
+		}
+
+		t, err := taskManager.Resolve(args[0])
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error finding task: " + err.Error()))
+			return
+		}
+		if t.Status == task.StatusCompleted {
+			fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("Task %d is already completed.", t.ID)))
+			return
+		}
+
+		plans, err := plan.Load()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading plans: " + err.Error()))
+			return
+		}
+		hours, err := loadWorkingHours()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render(err.Error()))
+			return
+		}
+
+		start := plan.NextFree(plans[plan.DateKey(day)], hours, day, time.Now())
+		if at != "" {
+			offset, err := plan.ParseClock(at)
+			if err != nil {
+				fmt.Println(ui.ErrorStyle.Render(err.Error()))
+				return
+			}
+			start = day.Add(offset)
+		}
+
+		plans.Add(plan.Block{TaskUUID: t.UUID, Start: start, Duration: duration})
+		if err := plan.Save(plans); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error saving plan: " + err.Error()))
+			return
+		}
+
+		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("Planned '%s' %s–%s", t.Title, start.Format("15:04"), start.Add(duration).Format("15:04"))))
+		fmt.Println()
+		showPlan(day)
+	},
+}
+
+var planRemoveCmd = &cobra.Command{
+	Use:   "remove [block]",
+	Short: "Remove a time block by its number in the plan",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dayFlag, _ := cmd.Flags().GetString("day")
+		day, err := parsePlanDay(dayFlag)
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render(err.Error()))
+			return
+		}
+
+		plans, err := plan.Load()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading plans: " + err.Error()))
+			return
+		}
+
+		key := plan.DateKey(day)
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 || n > len(plans[key]) {
+			fmt.Println(ui.ErrorStyle.Render(fmt.Sprintf("No block %s on %s", args[0], day.Format("Mon 02 Jan"))))
+			return
+		}
+
+		plans[key] = append(plans[key][:n-1], plans[key][n:]...)
+		if len(plans[key]) == 0 {
+			delete(plans, key)
+		}
+		if err := plan.Save(plans); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error saving plan: " + err.Error()))
+			return
+		}
+		fmt.Println(ui.SuccessStyle.Render("Block removed."))
+	},
+}
+
+var planClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove all time blocks of a day",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		dayFlag, _ := cmd.Flags().GetString("day")
+		day, err := parsePlanDay(dayFlag)
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render(err.Error()))
+			return
+		}
+
+		plans, err := plan.Load()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading plans: " + err.Error()))
+			return
+		}
+		delete(plans, plan.DateKey(day))
+		if err := plan.Save(plans); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error saving plan: " + err.Error()))
+			return
+		}
+		fmt.Println(ui.SuccessStyle.Render("Plan for " + day.Format("Mon 02 Jan") + " cleared."))
+	},
+}
+
+var planHoursCmd = &cobra.Command{
+	Use:   "hours [HH:MM-HH:MM]",
+	Short: "Show or set your working hours",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			hours, err := loadWorkingHours()
+			if err != nil {
+				fmt.Println(ui.ErrorStyle.Render(err.Error()))
+				return
+			}
+			fmt.Println(ui.InfoStyle.Render("Working hours: " + hours.String()))
+			return
+		}
+
+		hours, err := plan.ParseWorkingHours(args[0])
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render(err.Error()))
+			return
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading config: " + err.Error()))
+			return
+		}
+		cfg.Defaults.WorkingHours = hours.String()
+		if err := config.Save(cfg); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error saving config: " + err.Error()))
+			return
+		}
+		fmt.Println(ui.SuccessStyle.Render("Working hours set to " + hours.String()))
+	},
+}
+
+var planStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start a pomodoro for the current time block",
+	Long: `Start a timer for the block that is running now, lasting until the block
+ends, and mark its task in progress. With --wait, wait for the next block
+to begin if none is running.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		wait, _ := cmd.Flags().GetBool("wait")
+
+		plans, err := plan.Load()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading plans: " + err.Error()))
+			return
+		}
+
+		now := time.Now()
+		blocks := plans[plan.DateKey(now)]
+		i, ok := plan.Current(blocks, now, wait)
+		if !ok {
+			fmt.Println(ui.WarningStyle.Render("No time block is running now. Use --wait to wait for the next one."))
+			return
+		}
+		block := blocks[i]
+
+		t, err := taskByUUID(block.TaskUUID)
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error finding task: " + err.Error()))
+			return
+		}
+
+		if block.Start.After(now) {
+			fmt.Println(ui.InfoStyle.Render(fmt.Sprintf("Waiting for '%s' at %s (Ctrl+C to cancel)...", t.Title, block.Start.Format("15:04"))))
+			time.Sleep(time.Until(block.Start))
+		}
+
+		if t.Status != task.StatusInProgress {
+			if err := taskManager.Update(t.ID, task.StatusInProgress); err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Error starting task: " + err.Error()))
+				return
+			}
+		}
+
+		remaining := time.Until(block.End()).Round(time.Second)
+		runLoggedTimer(remaining, t.Title, timer.KindPomodoro, t.UUID)
+	},
+}
+
+// parsePlanDay turns a day argument into midnight of that day.
+func parsePlanDay(value string) (time.Time, error) {
+	due, err := task.ParseDue(value, time.Now())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid day '%s'", value)
+	}
+	return task.StartOfDay(*due), nil
+}
+
+func loadWorkingHours() (plan.WorkingHours, error) {
+	value := plan.DefaultWorkingHours
+	if hours := config.Current().Defaults.WorkingHours; hours != "" {
+		value = hours
+	}
+	return plan.ParseWorkingHours(value)
+}
+
+func taskByUUID(uuid string) (task.Task, error) {
+	tasks, err := taskManager.List()
+	if err != nil {
+		return task.Task{}, err
+	}
+	t, ok := task.NewDependencies(tasks).Lookup(uuid)
+	if !ok {
+		return task.Task{}, fmt.Errorf("the planned task no longer exists")
+	}
+	return t, nil
+}
+
+// showPlan prints the blocks of a day, a timeline and any problems.
+func showPlan(day time.Time) {
+	plans, err := plan.Load()
+	if err != nil {
+		fmt.Println(ui.ErrorStyle.Render("Error loading plans: " + err.Error()))
+		return
+	}
+	hours, err := loadWorkingHours()
+	if err != nil {
+		fmt.Println(ui.ErrorStyle.Render(err.Error()))
+		return
+	}
+	tasks, err := taskManager.List()
+	if err != nil {
+		fmt.Println(ui.ErrorStyle.Render("Error loading tasks: " + err.Error()))
+		return
+	}
+	deps := task.NewDependencies(tasks)
+
+	fmt.Println(ui.RenderTitle("Plan: " + day.Format("Monday 02 January")))
+
+	blocks := plans[plan.DateKey(day)]
+	if len(blocks) == 0 {
+		fmt.Println(ui.WarningStyle.Render("Nothing planned yet. Add a block with 'taskgo plan add <task> --at HH:MM --for 1h'."))
+		return
+	}
+
+	label := func(i int) string {
+		if t, ok := deps.Lookup(blocks[i].TaskUUID); ok {
+			return fmt.Sprintf("#%d %s", t.ID, t.Title)
+		}
+		return "(removed task)"
+	}
+
+	analysis := plan.Analyze(blocks, hours, day)
+	problem := make(map[int]bool)
+	for _, o := range analysis.Overlaps {
+		problem[o.A], problem[o.B] = true, true
+	}
+
+	for i, b := range blocks {
+		line := fmt.Sprintf("%2d. %s–%s  %-6s %s", i+1, b.Start.Format("15:04"), b.End().Format("15:04"), formatDuration(b.Duration), label(i))
+		if problem[i] {
+			line = ui.ErrorStyle.Render(line)
+		} else if t, ok := deps.Lookup(b.TaskUUID); ok {
+			line = renderTitle(task.Task{Title: line, Status: t.Status})
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+
+	fmt.Println(renderTimeline(blocks, hours, day, label, problem))
+	fmt.Println()
+
+	summary := fmt.Sprintf("Planned %s of %s working hours (%s)", formatDuration(analysis.Planned), formatDuration(analysis.Available), hours)
+	if analysis.OverAllocated() {
+		fmt.Println(ui.ErrorStyle.Render(summary + " - over-allocated by " + formatDuration(analysis.Planned-analysis.Available)))
+	} else {
+		fmt.Println(ui.SecondaryStyle.Render(summary))
+	}
+	for _, o := range analysis.Overlaps {
+		fmt.Println(ui.ErrorStyle.Render(fmt.Sprintf("Blocks %d and %d overlap", o.A+1, o.B+1)))
+	}
+	for _, i := range analysis.Outside {
+		fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("Block %d is outside working hours", i+1)))
+	}
+}
+
+// renderTimeline draws the day in timelineSlot rows covering the working
+// hours and every block. Blocks are labelled in the row they start in.
+func renderTimeline(blocks []plan.Block, hours plan.WorkingHours, day time.Time, label func(int) string, problem map[int]bool) string {
+	workStart, workEnd := hours.Window(day)
+	from, to := workStart, workEnd
+	for _, b := range blocks {
+		if b.Start.Before(from) {
+			from = b.Start
+		}
+		if b.End().After(to) {
+			to = b.End()
+		}
+	}
+	from = day.Add(from.Sub(day).Truncate(timelineSlot))
+
+	now := time.Now()
+	var lines []string
+	for slot := from; slot.Before(to); slot = slot.Add(timelineSlot) {
+		slotEnd := slot.Add(timelineSlot)
+
+		marker := "  "
+		if !now.Before(slot) && now.Before(slotEnd) {
+			marker = ui.HighlightStyle.Render("▶ ")
+		}
+
+		axis := ui.SecondaryStyle.Render("│")
+		if slot.Before(workStart) || !slot.Before(workEnd) {
+			axis = ui.SecondaryStyle.Render("┆")
+		}
+
+		var cells []string
+		for i, b := range blocks {
+			if !b.Start.Before(slotEnd) || !b.End().After(slot) {
+				continue
+			}
+			cell := "┃"
+			if !b.Start.Before(slot) {
+				cell = "┏ " + label(i)
+			}
+			if problem[i] {
+				cell = ui.ErrorStyle.Render(cell)
+			} else {
+				cell = ui.PrimaryStyle.Render(cell)
+			}
+			cells = append(cells, cell)
+		}
+
+		line := marker + ui.SecondaryStyle.Render(slot.Format("15:04")) + " " + axis
+		if len(cells) > 0 {
+			line += " " + strings.Join(cells, "  ")
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func init() {
+	for _, c := range []*cobra.Command{planAddCmd, planRemoveCmd, planClearCmd} {
+		c.Flags().String("day", "today", "Day of the plan: today, tomorrow, a weekday or YYYY-MM-DD")
+	}
+	planAddCmd.Flags().String("at", "", "Start time HH:MM (default: after the last block)")
+	planAddCmd.Flags().Duration("for", 0, "Estimated duration of the block (default: timer.pomodoro)")
+	planStartCmd.Flags().Bool("wait", false, "Wait for the next block if none is running")
+
+	planCmd.AddCommand(planAddCmd)
+	planCmd.AddCommand(planRemoveCmd)
+	planCmd.AddCommand(planClearCmd)
+	planCmd.AddCommand(planHoursCmd)
+	planCmd.AddCommand(planStartCmd)
+	rootCmd.AddCommand(planCmd)
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/MohakGupta2004/taskgo/internal/config"
 	"github.com/MohakGupta2004/taskgo/internal/timer"
 	"github.com/MohakGupta2004/taskgo/internal/ui"
 	"github.com/spf13/cobra"
@@ -13,13 +14,15 @@ import (
 var pomodoroCmd = &cobra.Command{
 	Use:   "pomodoro [duration]",
 	Short: "Start a pomodoro timer (default 25m)",
-	Long:  `Start a pomodoro timer. You can specify the duration using Go's time format (e.g., 25m, 1h, 1h30m). Default is 25m.`,
-	Args:  cobra.MaximumNArgs(1),
-	Run:   runPomodoro,
+	Long: `Start a pomodoro timer. You can specify the duration using Go's time format (e.g., 25m, 1h, 1h30m)
+or the name of a preset from timer.presets in the config file. Default is timer.pomodoro (25m).`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runPomodoro,
 }
 
 func runPomodoro(cmd *cobra.Command, args []string) {
-	duration := 25 * time.Minute
+	settings := config.Current()
+	duration := time.Duration(settings.Timer.Pomodoro)
 
 	if len(args) > 0 {
 		input := args[0]
@@ -27,19 +30,47 @@ func runPomodoro(cmd *cobra.Command, args []string) {
 		d, err := time.ParseDuration(input)
 		if err == nil {
 			duration = d
+		} else if preset, ok := settings.Timer.Presets[input]; ok {
+			duration = time.Duration(preset)
 		} else {
 			// Fallback: try parsing as integer minutes for backward compatibility
 			if m, err := strconv.Atoi(input); err == nil {
 				duration = time.Duration(m) * time.Minute
 			} else {
-				fmt.Println(ui.ErrorStyle.Render("Invalid time format. Use format like 25m, 1h, 1h30m"))
+				fmt.Println(ui.ErrorStyle.Render("Invalid time format. Use format like 25m, 1h, 1h30m or a preset name"))
 				return
 			}
 		}
 	}
 
-	t := timer.New(duration, fmt.Sprintf("Pomodoro (%s)", duration.String()))
+	runLoggedTimer(duration, fmt.Sprintf("Pomodoro (%s)", duration.String()), timer.KindPomodoro, "")
+}
+
+// runLoggedTimer runs a countdown and records it in the pomodoro log so it
+// shows up in 'taskgo stats'.
+func runLoggedTimer(duration time.Duration, title, kind, taskUUID string) *timer.Timer {
+	t := timer.New(duration, title)
+	logTimer(t, kind, taskUUID)
+	return t
+}
+
+// logTimer runs a prepared timer and records it in the pomodoro log.
+func logTimer(t *timer.Timer, kind, taskUUID string) {
+	start := time.Now()
 	t.Start()
+
+	record := timer.Record{
+		Start:     start,
+		End:       time.Now(),
+		Planned:   t.Duration,
+		Title:     t.Title,
+		Kind:      kind,
+		Completed: t.Finished,
+		TaskUUID:  taskUUID,
+	}
+	if err := timer.AppendLog(record); err != nil {
+		fmt.Println(ui.ErrorStyle.Render("Error recording pomodoro: " + err.Error()))
+	}
 }
 
 func init() {
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// promptLine prints a question and returns the trimmed answer. ok is false
+// when stdin is closed before an answer was given.
+func promptLine(question string) (answer string, ok bool) {
+	fmt.Print(question)
+	line, err := stdinReader.ReadString('\n')
+	if err != nil && line == "" {
+		fmt.Println()
+		return "", false
+	}
+	return strings.TrimSpace(line), true
+}
+
+// promptYesNo asks a yes/no question. An empty answer selects defaultYes;
+// a closed stdin always answers no.
+func promptYesNo(question string, defaultYes bool) bool {
+	hint := " [y/N] "
+	if defaultYes {
+		hint = " [Y/n] "
+	}
+
+	answer, ok := promptLine(question + hint)
+	if !ok {
+		return false
+	}
+
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		return true
+	case "n", "no":
+		return false
+	default:
+		return defaultYes
+	}
+}
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/MohakGupta2004/taskgo/internal/config"
 	"github.com/MohakGupta2004/taskgo/internal/ui"
@@ -10,11 +9,21 @@ import (
 )
 
 var removeCmd = &cobra.Command{
-	Use:   "remove [id]",
-	Short: "Remove a task",
-	Args:  cobra.ExactArgs(1),
+	Use:   "remove [ids|filter...]",
+	Short: "Remove tasks",
+	Long: `Remove one or more tasks. Use 'all' or '*' to remove every task in the current group.
+
+` + selectorHelp + `
+
+Examples:
+  taskgo remove 1
+  taskgo remove 3,5,9-14
+  taskgo remove status:completed --yes`,
+	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		if args[0] == "*" || args[0] == "all" {
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		if len(args) == 1 && (args[0] == "*" || args[0] == "all") {
 			ctx, err := config.LoadContext()
 			group := "General"
 			if err == nil && ctx.CurrentGroup != "" {
@@ -29,20 +38,25 @@ var removeCmd = &cobra.Command{
 			return
 		}
 
-		id, err := strconv.Atoi(args[0])
+		tasks, err := selectTasks(args)
 		if err != nil {
-			fmt.Println(ui.ErrorStyle.Render("Invalid ID"))
+			fmt.Println(ui.ErrorStyle.Render("Error selecting tasks: " + err.Error()))
+			return
+		}
+
+		if !confirmBulk("remove", tasks, yes) {
 			return
 		}
 
-		if err := taskManager.Remove(id); err != nil {
+		if err := taskManager.RemoveMany(taskIDs(tasks)); err != nil {
 			fmt.Println(ui.ErrorStyle.Render("Error removing task: " + err.Error()))
 			return
 		}
-		fmt.Println(ui.SuccessStyle.Render("Task removed successfully!"))
+		fmt.Println(ui.SuccessStyle.Render(pluralTasks(len(tasks)) + " removed successfully!"))
 	},
 }
 
 func init() {
+	removeCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt for bulk removals")
 	rootCmd.AddCommand(removeCmd)
 }
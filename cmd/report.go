@@ -0,0 +1,290 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/MohakGupta2004/taskgo/internal/config"
+	"github.com/MohakGupta2004/taskgo/internal/task"
+	"github.com/MohakGupta2004/taskgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// builtinReports are available to everyone. Reports with the same name in
+// the user's config take precedence.
+var builtinReports = map[string]config.Report{
+	"next": {
+		Description: "Open tasks, in progress first, then by due date",
+		Filter:      "status:open",
+		Sort:        "-status,due,created",
+		Columns:     []string{"id", "title", "group", "status", "due"},
+		GroupBy:     "none",
+		Limit:       10,
+	},
+	"overdue": {
+		Description: "Open tasks past their due date",
+		Filter:      "status:open due:overdue",
+		Sort:        "due",
+		Columns:     []string{"id", "title", "status", "due"},
+	},
+	"today": {
+		Description: "Open tasks due today or earlier",
+		Filter:      "status:open due:today",
+		Sort:        "due",
+		Columns:     []string{"id", "title", "status", "due"},
+	},
+	"recently-completed": {
+		Description: "Tasks completed in the last 7 days",
+		Filter:      "completed:7d",
+		Sort:        "-completed",
+		Columns:     []string{"id", "title", "group", "completed"},
+		GroupBy:     "none",
+	},
+	"stale": {
+		Description: "In-progress tasks unchanged for 7 days",
+		Filter:      "status:in-progress idle:7d",
+		Sort:        "created",
+		Columns:     []string{"id", "title", "created", "due"},
+	},
+}
+
+var reportCmd = &cobra.Command{
+	Use:   "report [name]",
+	Short: "Run a named report",
+	Long: `Run a saved report: a filter, sort order, column set and grouping.
+Without a name all available reports are listed.
+
+Built-in reports: next, overdue, today, recently-completed, stale.
+Define your own with 'taskgo report define'.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := config.Current()
+		if len(args) == 0 {
+			listReports(cfg)
+			return
+		}
+
+		report, ok := findReport(cfg, args[0])
+		if !ok {
+			fmt.Println(ui.ErrorStyle.Render(fmt.Sprintf("Report '%s' not found. Run 'taskgo report' to list reports.", args[0])))
+			return
+		}
+
+		if err := runReport(args[0], report); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error running report: " + err.Error()))
+		}
+	},
+}
+
+var reportDefineCmd = &cobra.Command{
+	Use:   "define [name]",
+	Short: "Save a named report in your config",
+	Long: `Save a named report. The filter uses the same terms as bulk operations.
+
+` + selectorHelp + `
+
+Examples:
+  taskgo report define backend --filter "status:open tag:backend" --sort due --group-by status
+  taskgo report define done-this-week --filter completed:7d --columns id,title,completed`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		report := config.Report{}
+		report.Description, _ = cmd.Flags().GetString("description")
+		report.Filter, _ = cmd.Flags().GetString("filter")
+		report.Sort, _ = cmd.Flags().GetString("sort")
+		report.Columns, _ = cmd.Flags().GetStringSlice("columns")
+		report.GroupBy, _ = cmd.Flags().GetString("group-by")
+		report.Limit, _ = cmd.Flags().GetInt("limit")
+
+		if err := validateReport(report); err != nil {
+			fmt.Println(ui.ErrorStyle.Render(err.Error()))
+			return
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading config: " + err.Error()))
+			return
+		}
+
+		if cfg.Reports == nil {
+			cfg.Reports = make(map[string]config.Report)
+		}
+		cfg.Reports[name] = report
+
+		if err := config.Save(cfg); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error saving config: " + err.Error()))
+			return
+		}
+		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("Report '%s' saved.", name)))
+	},
+}
+
+var reportDeleteCmd = &cobra.Command{
+	Use:   "delete [name]",
+	Short: "Delete a report from your config",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading config: " + err.Error()))
+			return
+		}
+
+		if _, ok := cfg.Reports[name]; !ok {
+			if _, builtin := builtinReports[name]; builtin {
+				fmt.Println(ui.ErrorStyle.Render(fmt.Sprintf("'%s' is a built-in report and cannot be deleted.", name)))
+			} else {
+				fmt.Println(ui.ErrorStyle.Render(fmt.Sprintf("Report '%s' not found.", name)))
+			}
+			return
+		}
+
+		delete(cfg.Reports, name)
+		if err := config.Save(cfg); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error saving config: " + err.Error()))
+			return
+		}
+		fmt.Println(ui.SuccessStyle.Render(fmt.Sprintf("Report '%s' deleted.", name)))
+	},
+}
+
+// findReport looks a report up in the user's config, then in the built-ins.
+func findReport(cfg *config.Config, name string) (config.Report, bool) {
+	if r, ok := cfg.Reports[name]; ok {
+		return r, true
+	}
+	r, ok := builtinReports[name]
+	return r, ok
+}
+
+func validateReport(r config.Report) error {
+	if r.Filter != "" {
+		if _, err := task.ParseSelector(strings.Fields(r.Filter)); err != nil {
+			return err
+		}
+	}
+	if _, err := task.ParseSort(r.Sort); err != nil {
+		return err
+	}
+	if _, err := parseColumns(r.Columns); err != nil {
+		return err
+	}
+	if r.GroupBy != "" && !slices.Contains(groupByOptions, r.GroupBy) {
+		return fmt.Errorf("invalid group-by '%s'. Use: %s", r.GroupBy, strings.Join(groupByOptions, ", "))
+	}
+	if r.Limit < 0 {
+		return fmt.Errorf("limit must not be negative")
+	}
+	return nil
+}
+
+// selectReportTasks applies a report's filter, sort order and limit.
+func selectReportTasks(r config.Report) ([]task.Task, error) {
+	sel := &task.Selector{}
+	if r.Filter != "" {
+		var err error
+		sel, err = task.ParseSelector(strings.Fields(r.Filter))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tasks, err := taskManager.Select(sel)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := task.ParseSort(r.Sort)
+	if err != nil {
+		return nil, err
+	}
+	task.SortTasks(tasks, keys)
+
+	if r.Limit > 0 && len(tasks) > r.Limit {
+		tasks = tasks[:r.Limit]
+	}
+	return tasks, nil
+}
+
+func runReport(name string, r config.Report) error {
+	if err := validateReport(r); err != nil {
+		return err
+	}
+
+	tasks, err := selectReportTasks(r)
+	if err != nil {
+		return err
+	}
+
+	title := "Report: " + name
+	if r.Description != "" {
+		title += "\n" + r.Description
+	}
+	fmt.Println(ui.RenderTitle(title))
+
+	if len(tasks) == 0 {
+		fmt.Println(ui.WarningStyle.Render("No tasks found."))
+		return nil
+	}
+
+	columns, _ := parseColumns(r.Columns)
+	groupBy := r.GroupBy
+	if groupBy == "" {
+		groupBy = "group"
+	}
+	renderTaskTables(tasks, columns, groupBy)
+	return nil
+}
+
+func listReports(cfg *config.Config) {
+	names := make(map[string]bool)
+	for name := range builtinReports {
+		names[name] = true
+	}
+	for name := range cfg.Reports {
+		names[name] = true
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	fmt.Println(ui.RenderTitle("Available Reports"))
+	for _, name := range sorted {
+		r, _ := findReport(cfg, name)
+		source := "built-in"
+		if _, custom := cfg.Reports[name]; custom {
+			source = "custom"
+		}
+
+		description := r.Description
+		if description == "" {
+			description = r.Filter
+		}
+		fmt.Printf("%s %s %s\n",
+			ui.PrimaryStyle.Render(fmt.Sprintf("%-20s", name)),
+			description,
+			ui.SecondaryStyle.Render("("+source+")"),
+		)
+	}
+}
+
+func init() {
+	reportDefineCmd.Flags().String("description", "", "Short description shown in the report list")
+	reportDefineCmd.Flags().StringP("filter", "f", "", "Filter terms, e.g. \"status:open group:work\"")
+	reportDefineCmd.Flags().StringP("sort", "s", "", "Sort by fields, e.g. status,-created")
+	reportDefineCmd.Flags().StringSliceP("columns", "c", nil, "Columns to show, e.g. id,title,status")
+	reportDefineCmd.Flags().String("group-by", "group", "Split tables by group, status or none")
+	reportDefineCmd.Flags().IntP("limit", "n", 0, "Maximum number of tasks (0 for all)")
+
+	reportCmd.AddCommand(reportDefineCmd)
+	reportCmd.AddCommand(reportDeleteCmd)
+	rootCmd.AddCommand(reportCmd)
+}
@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MohakGupta2004/taskgo/internal/task"
+	"github.com/MohakGupta2004/taskgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// reviewStep is one section of the weekly review.
+type reviewStep struct {
+	title string
+	match func(t task.Task, now time.Time) bool
+}
+
+var reviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "Step through a weekly review of tasks that need attention",
+	Long: `Walk through the tasks that tend to rot: stale todo tasks, tasks expiring
+soon, tasks without a group and tasks that have been in progress for a long
+time. For each task choose an action:
+
+  k  keep as is (default)
+  r  reschedule: set a new validity, e.g. 7d, 48h or none
+  m  move to another group
+  c  complete
+  a  archive: hide from lists and reports without deleting
+  q  quit the review
+
+Changes are saved immediately, so quitting keeps what was already reviewed.
+
+Examples:
+  taskgo review
+  taskgo review --stale 7d --running 3d`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		stale, _ := cmd.Flags().GetString("stale")
+		expiring, _ := cmd.Flags().GetString("expiring")
+		running, _ := cmd.Flags().GetString("running")
+
+		var staleAge, expiringAge, runningAge time.Duration
+		for _, f := range []struct {
+			name  string
+			value string
+			age   *time.Duration
+		}{{"stale", stale, &staleAge}, {"expiring", expiring, &expiringAge}, {"running", running, &runningAge}} {
+			d, err := task.ParseAge(f.value)
+			if err != nil {
+				fmt.Println(ui.ErrorStyle.Render(fmt.Sprintf("Invalid --%s: %s", f.name, err.Error())))
+				return
+			}
+			*f.age = d
+		}
+
+		steps := []reviewStep{
+			{"Stale todo tasks", func(t task.Task, now time.Time) bool {
+				return t.Status == task.StatusTodo && now.Sub(task.LastChange(t)) >= staleAge
+			}},
+			{"Expiring soon", func(t task.Task, now time.Time) bool {
+				return t.Status != task.StatusCompleted && t.ValidUntil != nil && t.ValidUntil.Sub(now) <= expiringAge
+			}},
+			{"Tasks without a group", func(t task.Task, now time.Time) bool {
+				return t.Status != task.StatusCompleted && (t.Group == "" || t.Group == "General")
+			}},
+			{"Long-running tasks", func(t task.Task, now time.Time) bool {
+				return t.Status == task.StatusInProgress && now.Sub(task.StatusSince(t)) >= runningAge
+			}},
+		}
+
+		tasks, err := taskManager.Select(&task.Selector{Archived: "no"})
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading tasks: " + err.Error()))
+			return
+		}
+
+		now := time.Now()
+		seen := make(map[int]bool)
+		sections := make([][]task.Task, len(steps))
+		total := 0
+		for i, step := range steps {
+			for _, t := range tasks {
+				if !seen[t.ID] && step.match(t, now) {
+					sections[i] = append(sections[i], t)
+					seen[t.ID] = true
+					total++
+				}
+			}
+		}
+
+		if total == 0 {
+			fmt.Println(ui.SuccessStyle.Render("Nothing to review. Your task list is in good shape! 🎉"))
+			return
+		}
+
+		fmt.Println(ui.RenderTitle("Weekly Review"))
+		fmt.Println(ui.SecondaryStyle.Render(fmt.Sprintf("%d tasks to review.", total)))
+
+		tally := make(map[string]int)
+		n := 0
+	review:
+		for i, step := range steps {
+			if len(sections[i]) == 0 {
+				continue
+			}
+			fmt.Println()
+			fmt.Println(ui.TreeBranchStyle.Render(fmt.Sprintf("%s (%d)", step.title, len(sections[i]))))
+
+			for _, t := range sections[i] {
+				n++
+				fmt.Println()
+				fmt.Printf("%s %s\n", ui.SecondaryStyle.Render(fmt.Sprintf("[%d/%d]", n, total)), renderReviewTask(t, now))
+
+				action, ok := reviewTask(t)
+				if !ok {
+					break review
+				}
+				tally[action]++
+			}
+		}
+
+		fmt.Println()
+		var parts []string
+		for _, action := range []string{"kept", "rescheduled", "moved", "completed", "archived"} {
+			if tally[action] > 0 {
+				parts = append(parts, fmt.Sprintf("%d %s", tally[action], action))
+			}
+		}
+		if len(parts) == 0 {
+			parts = append(parts, "no changes")
+		}
+		fmt.Println(ui.SuccessStyle.Render("Review done: " + strings.Join(parts, ", ") + "."))
+	},
+}
+
+// renderReviewTask summarises a task on one line with the facts that
+// matter when deciding what to do with it.
+func renderReviewTask(t task.Task, now time.Time) string {
+	group := t.Group
+	if group == "" {
+		group = "General"
+	}
+
+	facts := []string{group, renderStatus(t.Status), "idle " + formatDuration(now.Sub(task.LastChange(t)))}
+	if t.Status == task.StatusInProgress {
+		facts = append(facts, "in progress for "+formatDuration(now.Sub(task.StatusSince(t))))
+	}
+	if t.ValidUntil != nil {
+		if t.ValidUntil.After(now) {
+			facts = append(facts, "expires in "+formatDuration(t.ValidUntil.Sub(now)))
+		} else {
+			facts = append(facts, ui.ErrorStyle.Render("expired"))
+		}
+	}
+
+	return fmt.Sprintf("%s %s\n      %s",
+		ui.SecondaryStyle.Render(fmt.Sprintf("#%d", t.ID)),
+		renderTitle(t),
+		ui.SecondaryStyle.Render(strings.Join(facts, " · ")))
+}
+
+// reviewTask asks for and applies an action to a single task. It returns
+// the past-tense action for the summary; ok is false when the user quits.
+func reviewTask(t task.Task) (action string, ok bool) {
+	for {
+		answer, ok := promptLine("  [k]eep, [r]eschedule, [m]ove, [c]omplete, [a]rchive, [q]uit (k): ")
+		if !ok {
+			return "", false
+		}
+
+		var err error
+		switch strings.ToLower(answer) {
+		case "", "k", "keep":
+			return "kept", true
+		case "q", "quit":
+			return "", false
+		case "r", "reschedule":
+			validity, ok := promptLine("  Valid for (e.g. 7d, 48h, none): ")
+			if !ok {
+				return "", false
+			}
+			if validity != "none" {
+				d, perr := task.ParseAge(validity)
+				if perr != nil || d <= 0 {
+					fmt.Println(ui.ErrorStyle.Render("  Invalid duration"))
+					continue
+				}
+				validity = d.String()
+			}
+			if err = taskManager.UpdateValidityMany([]int{t.ID}, validity); err == nil {
+				action = "rescheduled"
+			}
+		case "m", "move":
+			group, ok := promptLine("  Move to group: ")
+			if !ok {
+				return "", false
+			}
+			if group == "" {
+				continue
+			}
+			if err = taskManager.UpdateGroupMany([]int{t.ID}, group); err == nil {
+				action = "moved"
+			}
+		case "c", "complete":
+			if err = taskManager.UpdateStatusMany([]int{t.ID}, task.StatusCompleted); err == nil {
+				action = "completed"
+			}
+		case "a", "archive":
+			if err = taskManager.ArchiveMany([]int{t.ID}); err == nil {
+				action = "archived"
+			}
+		default:
+			fmt.Println(ui.WarningStyle.Render("  Unknown action '" + answer + "'"))
+			continue
+		}
+
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("  Error updating task: " + err.Error()))
+			continue
+		}
+		fmt.Println(ui.SuccessStyle.Render("  " + strings.ToUpper(action[:1]) + action[1:]))
+		return action, true
+	}
+}
+
+func init() {
+	reviewCmd.Flags().String("stale", "14d", "Review todo tasks unchanged for at least this long")
+	reviewCmd.Flags().String("expiring", "2d", "Review tasks expiring within this long")
+	reviewCmd.Flags().String("running", "7d", "Review tasks in progress for at least this long")
+	rootCmd.AddCommand(reviewCmd)
+}
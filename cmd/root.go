@@ -3,10 +3,12 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"path/filepath"
+	"strings"
 
+	"github.com/MohakGupta2004/taskgo/internal/config"
 	"github.com/MohakGupta2004/taskgo/internal/storage"
 	"github.com/MohakGupta2004/taskgo/internal/task"
+	"github.com/MohakGupta2004/taskgo/internal/ui"
 	"github.com/spf13/cobra"
 )
 
@@ -27,12 +29,39 @@ func Execute() {
 }
 
 func init() {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		panic(err)
+	// Settings are read once a command runs, so --help and flag errors
+	// never load or migrate the config file
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		applySettings()
+
+		var store task.Storage
+		if storagePath, err := config.Current().TasksFile(); err == nil {
+			store = storage.NewJSONStorage(storagePath)
+		} else {
+			// Config commands must keep working so the file can be fixed
+			store = storage.Unavailable(err)
+		}
+		taskManager = task.NewManager(store)
+
+		if isConfigCommand(cmd) {
+			return
+		}
+		if err := settingsProblem(); err != nil {
+			fmt.Fprintln(os.Stderr, ui.WarningStyle.Render("Config problem (see 'taskgo config validate'):"))
+			for _, line := range strings.Split(err.Error(), "\n") {
+				fmt.Fprintln(os.Stderr, ui.WarningStyle.Render("  "+line))
+			}
+		}
 	}
+}
 
-	storagePath := filepath.Join(home, ".taskgo", "tasks.json")
-	store := storage.NewJSONStorage(storagePath)
-	taskManager = task.NewManager(store)
+// isConfigCommand reports whether cmd is 'taskgo config' or one of its
+// subcommands, which report config problems themselves.
+func isConfigCommand(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c == configCmd {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MohakGupta2004/taskgo/internal/search"
+	"github.com/MohakGupta2004/taskgo/internal/task"
+	"github.com/MohakGupta2004/taskgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// snippetRadius is how many characters of context are shown around a
+// match in the notes.
+const snippetRadius = 30
+
+var searchCmd = &cobra.Command{
+	Use:   "search [query]",
+	Short: "Fuzzy search tasks by title, notes, tags and group",
+	Long: `Search tasks with fuzzy matching over titles, notes, tags and groups.
+Every word of the query must match; results are ranked with title matches first.
+Completed and archived tasks are only included with --all.
+
+Examples:
+  taskgo search deploy
+  taskgo search dply prod --all`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		all, _ := cmd.Flags().GetBool("all")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		tasks, err := taskManager.List()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading tasks: " + err.Error()))
+			return
+		}
+
+		if !all {
+			var open []task.Task
+			for _, t := range tasks {
+				if t.Status != task.StatusCompleted && t.ArchivedAt == nil {
+					open = append(open, t)
+				}
+			}
+			tasks = open
+		}
+
+		query := strings.Join(args, " ")
+		results := search.NewIndex(tasks).Search(query, limit)
+		if len(results) == 0 {
+			fmt.Println(ui.WarningStyle.Render(fmt.Sprintf("No tasks match '%s'.", query)))
+			return
+		}
+
+		for _, r := range results {
+			fmt.Println(renderSearchResult(r))
+		}
+	},
+}
+
+// renderSearchResult prints the task title with matches highlighted, its
+// group and status, and a snippet for matches outside the title.
+func renderSearchResult(r search.Result) string {
+	title := r.Task.Title
+	var extra []string
+
+	for _, m := range r.Matches {
+		switch m.Field {
+		case search.FieldTitle:
+			title = highlight([]rune(m.Text), m.Positions)
+		case search.FieldNotes:
+			extra = append(extra, "notes: "+notesSnippet([]rune(m.Text), m.Positions))
+		default:
+			extra = append(extra, string(m.Field)+": "+highlight([]rune(m.Text), m.Positions))
+		}
+	}
+
+	group := r.Task.Group
+	if group == "" {
+		group = "General"
+	}
+
+	line := fmt.Sprintf("%s %s  %s %s",
+		ui.SecondaryStyle.Render(fmt.Sprintf("%4d", r.Task.ID)),
+		title,
+		ui.TreeBranchStyle.Render("["+group+"]"),
+		renderStatus(r.Task.Status),
+	)
+	for _, e := range extra {
+		line += "\n     " + ui.SecondaryStyle.Render("↳ ") + e
+	}
+	return line
+}
+
+// highlight styles the runes at the given positions.
+func highlight(text []rune, positions []int) string {
+	marked := make(map[int]bool, len(positions))
+	for _, p := range positions {
+		marked[p] = true
+	}
+
+	var b strings.Builder
+	for i, r := range text {
+		if marked[i] {
+			b.WriteString(ui.HighlightStyle.Render(string(r)))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// notesSnippet returns the highlighted part of the notes around the first
+// match, on a single line.
+func notesSnippet(text []rune, positions []int) string {
+	if len(positions) == 0 {
+		return ""
+	}
+
+	start := max(positions[0]-snippetRadius, 0)
+	end := min(positions[len(positions)-1]+snippetRadius+1, len(text))
+
+	var shifted []int
+	for _, p := range positions {
+		if p >= start && p < end {
+			shifted = append(shifted, p-start)
+		}
+	}
+
+	window := make([]rune, end-start)
+	for i, r := range text[start:end] {
+		if r == '\n' || r == '\r' || r == '\t' {
+			r = ' '
+		}
+		window[i] = r
+	}
+
+	snippet := highlight(window, shifted)
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(text) {
+		snippet += "…"
+	}
+	return snippet
+}
+
+func init() {
+	searchCmd.Flags().BoolP("all", "a", false, "Include completed and archived tasks")
+	searchCmd.Flags().IntP("limit", "n", 20, "Maximum number of results (0 for all)")
+	rootCmd.AddCommand(searchCmd)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/MohakGupta2004/taskgo/internal/config"
 	"github.com/MohakGupta2004/taskgo/internal/timer"
 	"github.com/MohakGupta2004/taskgo/internal/ui"
 	"github.com/spf13/cobra"
@@ -12,13 +13,15 @@ import (
 var sessionCmd = &cobra.Command{
 	Use:   "session [duration]",
 	Short: "Start a pomodoro session (alternating work/break)",
-	Long:  `Start a pomodoro session that alternates between 25m work and 5m break intervals until the specified duration is reached. Default duration is 2h.`,
-	Args:  cobra.MaximumNArgs(1),
-	Run:   runSession,
+	Long: `Start a pomodoro session that alternates between work and break intervals until the specified duration is reached.
+The lengths default to timer.work (25m), timer.break (5m) and timer.session (2h) of the config file.`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runSession,
 }
 
 func runSession(cmd *cobra.Command, args []string) {
-	totalDuration := 2 * time.Hour
+	settings := config.Current()
+	totalDuration := time.Duration(settings.Timer.Session)
 
 	if len(args) > 0 {
 		d, err := time.ParseDuration(args[0])
@@ -32,8 +35,8 @@ func runSession(cmd *cobra.Command, args []string) {
 	startTime := time.Now()
 	endTime := startTime.Add(totalDuration)
 
-	workDuration := 25 * time.Minute
-	breakDuration := 5 * time.Minute
+	workDuration := time.Duration(settings.Timer.Work)
+	breakDuration := time.Duration(settings.Timer.Break)
 
 	for {
 		if time.Now().After(endTime) {
@@ -48,8 +51,7 @@ func runSession(cmd *cobra.Command, args []string) {
 		}
 
 		if currentWorkDuration > 0 {
-			t := timer.New(currentWorkDuration, "Session: Work")
-			t.Start()
+			runLoggedTimer(currentWorkDuration, "Session: Work", timer.KindWork, "")
 		}
 
 		if time.Now().After(endTime) {
@@ -64,8 +66,7 @@ func runSession(cmd *cobra.Command, args []string) {
 		}
 
 		if currentBreakDuration > 0 {
-			t := timer.New(currentBreakDuration, "Session: Break")
-			t.Start()
+			runLoggedTimer(currentBreakDuration, "Session: Break", timer.KindBreak, "")
 		}
 	}
 
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MohakGupta2004/taskgo/internal/task"
+	"github.com/MohakGupta2004/taskgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// maxNotesWidth keeps notes readable on very wide terminals.
+const maxNotesWidth = 100
+
+var showCmd = &cobra.Command{
+	Use:   "show [id|uuid]",
+	Short: "Show all details and history of a task",
+	Long: `Show every field of a task, computed state (time remaining, age, time spent),
+its notes rendered as Markdown, its annotations and its change history.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		t, err := taskManager.Resolve(args[0])
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading task: " + err.Error()))
+			return
+		}
+
+		tasks, err := taskManager.List()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading tasks: " + err.Error()))
+			return
+		}
+
+		fmt.Println(renderTaskDetails(t, task.NewDependencies(tasks)))
+	},
+}
+
+// renderTaskDetails builds the full detail view of a task.
+func renderTaskDetails(t task.Task, deps *task.Dependencies) string {
+	var b strings.Builder
+
+	b.WriteString(ui.RenderTitle(fmt.Sprintf("#%d %s", t.ID, t.Title)))
+	b.WriteString("\n")
+
+	group := t.Group
+	if group == "" {
+		group = "General"
+	}
+
+	writeField(&b, "UUID", t.UUID)
+	writeField(&b, "Group", group)
+	writeField(&b, "Status", renderStatus(t.Status))
+	if len(t.Tags) > 0 {
+		writeField(&b, "Tags", strings.Join(t.Tags, ", "))
+	}
+	now := time.Now()
+	writeField(&b, "Created", t.CreatedAt.Format(displayTimeFormat))
+	if t.CompletedAt != nil {
+		writeField(&b, "Completed", t.CompletedAt.Format(displayTimeFormat))
+	}
+	if t.ArchivedAt != nil {
+		writeField(&b, "Archived", t.ArchivedAt.Format(displayTimeFormat))
+	}
+	if t.ValidUntil != nil {
+		state := "expired"
+		if remaining := t.ValidUntil.Sub(now); remaining > 0 {
+			state = formatDuration(remaining) + " left"
+		}
+		writeField(&b, "Valid until", fmt.Sprintf("%s (%s)", t.ValidUntil.Format(displayTimeFormat), state))
+	}
+	if t.Due != nil {
+		state := formatDuration(t.Due.Sub(now)) + " left"
+		if task.IsOverdue(t, now) {
+			state = ui.ErrorStyle.Render("overdue by " + formatDuration(now.Sub(*t.Due)))
+		}
+		writeField(&b, "Due", fmt.Sprintf("%s (%s)", t.Due.Format(displayTimeFormat), state))
+	}
+	if len(t.DependsOn) > 0 {
+		var names []string
+		for _, uuid := range t.DependsOn {
+			if dep, ok := deps.Lookup(uuid); ok {
+				names = append(names, fmt.Sprintf("#%d %s (%s)", dep.ID, dep.Title, renderStatus(dep.Status)))
+			} else {
+				names = append(names, shortUUID(uuid)+" (removed)")
+			}
+		}
+		writeField(&b, "Depends on", strings.Join(names, ", "))
+	}
+	if n := deps.Blocking(t); n > 0 {
+		writeField(&b, "Blocks", fmt.Sprintf("%d open task(s)", n))
+	}
+	writeField(&b, "Age", formatDuration(now.Sub(t.CreatedAt)))
+	if spent := task.TimeSpent(t, now); spent > 0 {
+		writeField(&b, "Time spent", formatDuration(spent))
+	}
+
+	if t.Notes != "" {
+		b.WriteString("\n")
+		b.WriteString(ui.TreeBranchStyle.Render("Notes"))
+		b.WriteString("\n")
+		b.WriteString(ui.RenderMarkdown(t.Notes, min(ui.TerminalWidth(), maxNotesWidth)))
+		b.WriteString("\n")
+	}
+
+	if len(t.Annotations) > 0 {
+		b.WriteString("\n")
+		b.WriteString(ui.TreeBranchStyle.Render("Annotations"))
+		b.WriteString("\n")
+		for _, a := range t.Annotations {
+			b.WriteString(ui.SecondaryStyle.Render(a.Time.Format(displayTimeFormat)))
+			b.WriteString("  ")
+			b.WriteString(ui.RenderMarkdown(a.Text, 0))
+			b.WriteString("\n")
+		}
+	}
+
+	if len(t.History) > 0 {
+		b.WriteString("\n")
+		b.WriteString(ui.TreeBranchStyle.Render("History"))
+		b.WriteString("\n")
+		for _, c := range t.History {
+			b.WriteString(ui.SecondaryStyle.Render(c.Time.Format(displayTimeFormat)))
+			b.WriteString("  ")
+			b.WriteString(describeChange(c))
+			b.WriteString("\n")
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// describeChange renders a history entry as a human readable sentence.
+func describeChange(c task.Change) string {
+	value := func(v string) string {
+		if v == "" {
+			return "none"
+		}
+		if c.Field == task.FieldValidUntil || c.Field == task.FieldDue || c.Field == task.FieldArchivedAt {
+			if ts, err := time.Parse(time.RFC3339, v); err == nil {
+				return ts.Format(displayTimeFormat)
+			}
+		}
+		if c.Field == task.FieldStatus {
+			return renderStatus(task.TaskStatus(v))
+		}
+		if c.Field == task.FieldDependsOn {
+			var short []string
+			for _, uuid := range strings.Split(v, ",") {
+				short = append(short, shortUUID(uuid))
+			}
+			return strings.Join(short, ", ")
+		}
+		return v
+	}
+
+	switch {
+	case c.Field == task.FieldNotes:
+		return "notes edited"
+	case c.Field == task.FieldStatus && c.From == "":
+		return "created as " + value(c.To)
+	default:
+		return fmt.Sprintf("%s: %s → %s", strings.ReplaceAll(c.Field, "_", " "), value(c.From), value(c.To))
+	}
+}
+
+// shortUUID returns the first block of a UUID for compact display.
+func shortUUID(uuid string) string {
+	if len(uuid) > 8 {
+		return uuid[:8]
+	}
+	return uuid
+}
+
+// formatDuration renders a duration in days, hours and minutes.
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
+func writeField(b *strings.Builder, label, value string) {
+	b.WriteString(ui.SecondaryStyle.Render(fmt.Sprintf("%-12s", label+":")))
+	b.WriteString(" ")
+	b.WriteString(value)
+	b.WriteString("\n")
+}
+
+func init() {
+	rootCmd.AddCommand(showCmd)
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/MohakGupta2004/taskgo/internal/standup"
+	"github.com/MohakGupta2004/taskgo/internal/task"
+	"github.com/MohakGupta2004/taskgo/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var standupCmd = &cobra.Command{
+	Use:   "standup",
+	Short: "Generate a daily standup summary",
+	Long: `Summarise what you finished since the previous working day, what is in
+progress and what is blocked on unfinished dependencies, grouped by group.
+
+The output is Markdown by default; use --format text for plain text or
+--template to render your own Go text/template. Templates receive:
+
+  .Date, .Since                 time.Time
+  .Done, .Doing, .Blocked       counts
+  .Groups                       list of {Name, Done, Doing, Blocked}
+  items                         {Task, BlockedBy}
+
+and the helpers titles (joins task titles), join and date.
+
+Examples:
+  taskgo standup
+  taskgo standup --since 3d --format text
+  taskgo standup -g backend --template ~/.taskgo/standup.tmpl`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		templatePath, _ := cmd.Flags().GetString("template")
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		groups, _ := cmd.Flags().GetStringSlice("group")
+
+		text, ok := standup.Formats[format]
+		if !ok {
+			fmt.Println(ui.ErrorStyle.Render(fmt.Sprintf("Unknown format '%s' (use markdown or text)", format)))
+			return
+		}
+		name := format
+		if templatePath != "" {
+			data, err := os.ReadFile(templatePath)
+			if err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Error reading template: " + err.Error()))
+				return
+			}
+			text, name = string(data), templatePath
+		}
+
+		tmpl, err := standup.Parse(name, text)
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render(err.Error()))
+			return
+		}
+
+		now := time.Now()
+		since := standup.PreviousWorkday(now)
+		if sinceFlag != "" {
+			age, err := task.ParseAge(sinceFlag)
+			if err != nil {
+				fmt.Println(ui.ErrorStyle.Render("Invalid --since: " + err.Error()))
+				return
+			}
+			since = now.Add(-age)
+		}
+
+		tasks, err := taskManager.Select(&task.Selector{Groups: groups, Archived: "any"})
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading tasks: " + err.Error()))
+			return
+		}
+
+		// Output is meant to be pasted elsewhere, so it is left unstyled.
+		if err := standup.Render(os.Stdout, tmpl, standup.Build(tasks, since, now)); err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error rendering standup: " + err.Error()))
+		}
+	},
+}
+
+func init() {
+	standupCmd.Flags().StringP("format", "f", "markdown", "Output format: markdown or text")
+	standupCmd.Flags().StringP("template", "t", "", "Render with a custom text/template file")
+	standupCmd.Flags().String("since", "", "Report completions within this age, e.g. 1d or 36h (default: since the previous working day)")
+	standupCmd.Flags().StringSliceP("group", "g", nil, "Only include these groups")
+	rootCmd.AddCommand(standupCmd)
+}
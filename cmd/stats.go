@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/MohakGupta2004/taskgo/internal/stats"
+	"github.com/MohakGupta2004/taskgo/internal/timer"
+	"github.com/MohakGupta2004/taskgo/internal/ui"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show productivity statistics",
+	Long: `Show completion history and focus statistics: tasks completed per day,
+a contribution heatmap, streaks, lead time (created → completed) per group
+and pomodoro counts.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		days, _ := cmd.Flags().GetInt("days")
+		weeks, _ := cmd.Flags().GetInt("weeks")
+		if days < 1 || weeks < 1 {
+			fmt.Println(ui.ErrorStyle.Render("--days and --weeks must be positive"))
+			return
+		}
+
+		tasks, err := taskManager.List()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading tasks: " + err.Error()))
+			return
+		}
+
+		records, err := timer.LoadLog()
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render("Error loading pomodoro log: " + err.Error()))
+			return
+		}
+
+		now := time.Now()
+		completions := stats.CompletionsByDay(tasks)
+		series := stats.Series(completions, days, now)
+		total := 0
+		for _, c := range series {
+			total += c
+		}
+
+		fmt.Println(ui.RenderTitle("Productivity Stats"))
+
+		fmt.Println(ui.TreeBranchStyle.Render(fmt.Sprintf("Completed per day (last %d days)", days)))
+		fmt.Printf("%s  %s\n\n", ui.Sparkline(series), ui.SecondaryStyle.Render(fmt.Sprintf("%d total", total)))
+
+		fmt.Println(ui.TreeBranchStyle.Render("Completions"))
+		fmt.Println(ui.Heatmap(func(day time.Time) int { return completions[stats.Day(day)] }, weeks, now))
+		fmt.Println()
+
+		current, longest := stats.Streaks(completions, now)
+		fmt.Println(ui.TreeBranchStyle.Render("Streaks"))
+		writeStat("Current", pluralDays(current))
+		writeStat("Longest", pluralDays(longest))
+		fmt.Println()
+
+		pomodoros := stats.SummarisePomodoros(records, now)
+		fmt.Println(ui.TreeBranchStyle.Render("Pomodoros"))
+		writeStat("Today", strconv.Itoa(pomodoros.Today))
+		writeStat("Last 7 days", strconv.Itoa(pomodoros.Week))
+		writeStat("Total", fmt.Sprintf("%d (%s focused)", pomodoros.Total, formatDuration(pomodoros.Focused)))
+		if pomodoros.Quitters > 0 {
+			writeStat("Quit early", strconv.Itoa(pomodoros.Quitters))
+		}
+		fmt.Printf("%s  %s\n\n", ui.Sparkline(stats.Series(pomodoros.PerDay, days, now)), ui.SecondaryStyle.Render(fmt.Sprintf("per day, last %d days", days)))
+
+		leadTimes := stats.LeadTimes(tasks)
+		fmt.Println(ui.TreeBranchStyle.Render("Lead time (created → completed)"))
+		if len(leadTimes) == 0 {
+			fmt.Println(ui.SecondaryStyle.Render("No completed tasks yet."))
+			return
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Group", "Completed", "p50", "p90", "Max"})
+		table.SetBorder(true)
+		table.SetCenterSeparator("|")
+		table.SetColumnSeparator("|")
+		table.SetRowSeparator("-")
+		table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+		table.SetAlignment(tablewriter.ALIGN_LEFT)
+		for _, lt := range leadTimes {
+			table.Append([]string{
+				lt.Group,
+				strconv.Itoa(lt.Count),
+				formatDuration(lt.P50),
+				formatDuration(lt.P90),
+				formatDuration(lt.Max),
+			})
+		}
+		table.Render()
+	},
+}
+
+func writeStat(label, value string) {
+	fmt.Printf("%s %s\n", ui.SecondaryStyle.Render(fmt.Sprintf("  %-12s", label+":")), value)
+}
+
+func pluralDays(n int) string {
+	if n == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", n)
+}
+
+func init() {
+	statsCmd.Flags().Int("days", 30, "Number of days shown in the sparklines")
+	statsCmd.Flags().Int("weeks", 26, "Number of weeks shown in the heatmap")
+	rootCmd.AddCommand(statsCmd)
+}
@@ -2,8 +2,6 @@ package cmd
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
 
 	"github.com/MohakGupta2004/taskgo/internal/task"
 	"github.com/MohakGupta2004/taskgo/internal/ui"
@@ -11,38 +9,45 @@ import (
 )
 
 var updateCmd = &cobra.Command{
-	Use:   "update [id] [status]",
+	Use:   "update [ids|filter...] [status]",
 	Short: "Update task status (todo, in-progress, completed)",
-	Args:  cobra.ExactArgs(2),
+	Long: `Update the status of one or more tasks.
+
+` + selectorHelp + `
+
+Examples:
+  taskgo update 1 in-progress
+  taskgo update 3,5,9-14 completed
+  taskgo update status:in-progress group:work completed --yes`,
+	Args: cobra.MinimumNArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
-		id, err := strconv.Atoi(args[0])
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		status, err := task.ParseStatus(args[len(args)-1])
+		if err != nil {
+			fmt.Println(ui.ErrorStyle.Render(err.Error()))
+			return
+		}
+
+		tasks, err := selectTasks(args[:len(args)-1])
 		if err != nil {
-			fmt.Println(ui.ErrorStyle.Render("Invalid ID"))
+			fmt.Println(ui.ErrorStyle.Render("Error selecting tasks: " + err.Error()))
 			return
 		}
 
-		statusStr := strings.ToLower(args[1])
-		var status task.TaskStatus
-		switch statusStr {
-		case "todo":
-			status = task.StatusTodo
-		case "in-progress":
-			status = task.StatusInProgress
-		case "completed":
-			status = task.StatusCompleted
-		default:
-			fmt.Println(ui.ErrorStyle.Render("Invalid status. Use: todo, in-progress, completed"))
+		if !confirmBulk(fmt.Sprintf("mark as %s", status), tasks, yes) {
 			return
 		}
 
-		if err := taskManager.Update(id, status); err != nil {
+		if err := taskManager.UpdateStatusMany(taskIDs(tasks), status); err != nil {
 			fmt.Println(ui.ErrorStyle.Render("Error updating task: " + err.Error()))
 			return
 		}
-		fmt.Println(ui.SuccessStyle.Render("Task updated successfully!"))
+		fmt.Println(ui.SuccessStyle.Render(pluralTasks(len(tasks)) + " updated successfully!"))
 	},
 }
 
 func init() {
+	updateCmd.Flags().BoolP("yes", "y", false, "Skip the confirmation prompt for bulk updates")
 	rootCmd.AddCommand(updateCmd)
 }
@@ -0,0 +1,574 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the config file inside the config directory.
+const FileName = "config.yaml"
+
+// BackendJSON stores tasks in a JSON file, the only backend so far.
+const BackendJSON = "json"
+
+// ColorNames lists the theme colors that can be overridden.
+var ColorNames = []string{"primary", "secondary", "success", "warning", "error", "pending"}
+
+// Config holds taskgo's settings, read from config.yaml.
+type Config struct {
+	Storage  Storage           `yaml:"storage"`
+	Defaults Defaults          `yaml:"defaults"`
+	Timer    Timer             `yaml:"timer"`
+	Theme    Theme             `yaml:"theme"`
+	Notifier Notifier          `yaml:"notifier"`
+	Flows    Flows             `yaml:"flows"`
+	Reports  map[string]Report `yaml:"reports,omitempty"`
+
+	// dataErr is why the files tasks and flows are kept in are unknown
+	dataErr error
+}
+
+// Storage tells where tasks are kept.
+type Storage struct {
+	Backend string `yaml:"backend"`
+	// Path is the tasks file; tasks.json in the data directory if empty.
+	Path string `yaml:"path,omitempty"`
+}
+
+// Defaults holds the defaults of task commands.
+type Defaults struct {
+	// GroupValidity maps a group to how long its new tasks stay valid.
+	GroupValidity map[string]string `yaml:"group_validity"`
+	ListColumns   []string          `yaml:"list_columns,omitempty"`
+	ListSort      string            `yaml:"list_sort,omitempty"`
+	// WorkingHours is the daily planning window, e.g. "09:00-17:00".
+	WorkingHours string `yaml:"working_hours"`
+}
+
+// Timer holds the default lengths of timers. Presets name lengths that can
+// be passed to 'taskgo pomodoro' instead of a duration.
+type Timer struct {
+	Pomodoro Duration            `yaml:"pomodoro"`
+	Session  Duration            `yaml:"session"`
+	Work     Duration            `yaml:"work"`
+	Break    Duration            `yaml:"break"`
+	Flow     Duration            `yaml:"flow"`
+	Presets  map[string]Duration `yaml:"presets,omitempty"`
+}
+
+// Theme picks the color theme and overrides single colors of it.
+type Theme struct {
+	Name   string            `yaml:"name"`
+	Colors map[string]string `yaml:"colors,omitempty"`
+}
+
+// Notifier tells how the end of a timer is announced. Command is run with
+// the shell and gets the timer title in $TASKGO_TIMER.
+type Notifier struct {
+	Beeps   int    `yaml:"beeps"`
+	Desktop bool   `yaml:"desktop"`
+	Command string `yaml:"command,omitempty"`
+}
+
+// Flows holds the settings of flows.
+type Flows struct {
+	// Path is the flows file; flows.json in the data directory if empty.
+	Path string `yaml:"path,omitempty"`
+	// Browser is the registry entry flows use unless they name their own.
+	Browser string `yaml:"browser,omitempty"`
+	// Browsers adds to or overrides the built-in browsers used by flows.
+	Browsers map[string]Browser `yaml:"browsers,omitempty"`
+	// HostsFile is where the distraction blocker writes; the system hosts
+	// file if empty.
+	HostsFile string `yaml:"hosts_file,omitempty"`
+}
+
+// Browser tells flows how to open URLs in a new window of a browser.
+// "{profile}" in ProfileArgs is replaced with Profile.
+type Browser struct {
+	Binary      string   `json:"binary" yaml:"binary"`
+	Args        []string `json:"args,omitempty" yaml:"args,omitempty"`
+	NewWindow   []string `json:"new_window,omitempty" yaml:"new_window,omitempty"`
+	Kiosk       []string `json:"kiosk,omitempty" yaml:"kiosk,omitempty"`
+	Profile     string   `json:"profile,omitempty" yaml:"profile,omitempty"`
+	ProfileArgs []string `json:"profile_args,omitempty" yaml:"profile_args,omitempty"`
+}
+
+// Report is a saved list invocation run with `taskgo report <name>`.
+type Report struct {
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Filter      string   `json:"filter,omitempty" yaml:"filter,omitempty"`
+	Sort        string   `json:"sort,omitempty" yaml:"sort,omitempty"`
+	Columns     []string `json:"columns,omitempty" yaml:"columns,omitempty"`
+	GroupBy     string   `json:"group_by,omitempty" yaml:"group_by,omitempty"`
+	Limit       int      `json:"limit,omitempty" yaml:"limit,omitempty"`
+}
+
+// Duration is a time.Duration written as "25m" rather than "25m0s".
+type Duration time.Duration
+
+// String formats the duration without trailing zero units.
+func (d Duration) String() string {
+	s := time.Duration(d).String()
+	if strings.HasSuffix(s, "m0s") {
+		s = s[:len(s)-2]
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = s[:len(s)-2]
+	}
+	return s
+}
+
+// MarshalYAML writes the duration as a string.
+func (d Duration) MarshalYAML() (interface{}, error) {
+	return d.String(), nil
+}
+
+// UnmarshalYAML parses a duration such as "1h30m".
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	var s string
+	if err := value.Decode(&s); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("line %d: invalid duration '%s'", value.Line, s)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// Default returns the settings used when nothing is configured.
+func Default() *Config {
+	return &Config{
+		Storage: Storage{Backend: BackendJSON},
+		Defaults: Defaults{
+			GroupValidity: map[string]string{"General": "24h"},
+			WorkingHours:  "09:00-17:00",
+		},
+		Timer: Timer{
+			Pomodoro: Duration(25 * time.Minute),
+			Session:  Duration(2 * time.Hour),
+			Work:     Duration(25 * time.Minute),
+			Break:    Duration(5 * time.Minute),
+			Flow:     Duration(4 * time.Hour),
+		},
+		Theme:    Theme{Name: "default"},
+		Notifier: Notifier{Beeps: 3},
+	}
+}
+
+// Dir returns the directory holding the config file: $TASKGO_HOME if set,
+// otherwise taskgo inside $XDG_CONFIG_HOME or ~/.config.
+func Dir() (string, error) {
+	if os.Getenv(HomeEnv) != "" {
+		return DataDir()
+	}
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "taskgo"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "taskgo"), nil
+}
+
+// Path returns the location of the config file.
+func Path() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, FileName), nil
+}
+
+// Parse reads a config file on top of the defaults, so that missing keys
+// keep their default values. Unknown keys are rejected.
+func Parse(data []byte) (*Config, error) {
+	cfg := Default()
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Load reads the config file. Without one, settings are taken over from
+// the context file of older versions.
+func Load() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return migrateContext()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// loadLenient reads the config file ignoring unknown keys.
+func loadLenient() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := Default()
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+var (
+	current     *Config
+	currentErr  error
+	currentOnce sync.Once
+)
+
+// Current returns the settings of this run. Invalid settings fall back to
+// their defaults one by one. Where tasks and flows are stored never falls
+// back: if the config file cannot be read or names an unknown storage
+// backend, TasksFile and FlowsFile fail instead of using other files.
+// LoadError tells what was wrong.
+func Current() *Config {
+	currentOnce.Do(func() {
+		current, currentErr = loadCurrent()
+	})
+	return current
+}
+
+// loadCurrent reads the settings for Current.
+func loadCurrent() (*Config, error) {
+	var problems []string
+	cfg, err := Load()
+	if err != nil {
+		// Unknown keys are only typos; the rest of the file still counts
+		lenient, lerr := loadLenient()
+		if lerr != nil {
+			cfg = Default()
+			cfg.dataErr = err
+			return cfg, fmt.Errorf("%w\nUsing the default settings; tasks and flows are unavailable until the file is fixed", err)
+		}
+		cfg = lenient
+		problems = append(problems, err.Error())
+	}
+
+	problems = append(problems, cfg.problems(true)...)
+	if len(problems) > 0 {
+		problems = append(problems, "Invalid keys use their default values")
+	}
+	if cfg.Storage.Backend != BackendJSON {
+		cfg.dataErr = fmt.Errorf("unknown storage backend '%s'", cfg.Storage.Backend)
+		problems = append([]string{fmt.Sprintf("storage.backend: unknown backend '%s'. Use: %s", cfg.Storage.Backend, BackendJSON)}, problems...)
+		problems = append(problems, "Tasks and flows are unavailable until storage.backend is fixed")
+	}
+	if len(problems) > 0 {
+		return cfg, errors.New(strings.Join(problems, "\n"))
+	}
+	return cfg, nil
+}
+
+// LoadError returns what Current found wrong with the config file, if
+// anything.
+func LoadError() error {
+	Current()
+	return currentErr
+}
+
+// Save writes cfg to the config file. Comments and the order of keys
+// already in the file are kept.
+func Save(cfg *Config) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+
+	var doc yaml.Node
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	var updated yaml.Node
+	if err := updated.Encode(cfg); err != nil {
+		return err
+	}
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		mergeNode(doc.Content[0], &updated)
+	} else {
+		updated.HeadComment = fileHeader
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{&updated}}
+	}
+
+	if err := WriteDocument(&doc); err != nil {
+		return err
+	}
+	current, currentErr = cfg, nil
+	return nil
+}
+
+const fileHeader = "taskgo configuration. Edit with 'taskgo config edit' or\n'taskgo config set <key> <value>'; 'taskgo config list' shows every key."
+
+// WriteDocument writes a config document to the config file.
+func WriteDocument(doc *yaml.Node) error {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+	return Write(buf.Bytes())
+}
+
+// Write replaces the config file with data as is.
+func Write(data []byte) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// mergeNode makes dst hold the values of src, keeping the comments and key
+// order of dst. Keys missing from src are dropped.
+func mergeNode(dst, src *yaml.Node) {
+	if dst.Kind != yaml.MappingNode || src.Kind != yaml.MappingNode {
+		head, line, foot := dst.HeadComment, dst.LineComment, dst.FootComment
+		*dst = *src
+		dst.HeadComment, dst.LineComment, dst.FootComment = head, line, foot
+		return
+	}
+
+	var content []*yaml.Node
+	merged := make(map[string]bool)
+	for i := 0; i+1 < len(dst.Content); i += 2 {
+		key, value := dst.Content[i], dst.Content[i+1]
+		if newValue := lookup(src, key.Value); newValue != nil {
+			mergeNode(value, newValue)
+			content = append(content, key, value)
+			merged[key.Value] = true
+		}
+	}
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		if !merged[src.Content[i].Value] {
+			content = append(content, src.Content[i], src.Content[i+1])
+		}
+	}
+	dst.Content = content
+}
+
+// lookup returns the value of key in a mapping node.
+func lookup(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// Validate checks the settings that can be checked without knowing the
+// rest of taskgo. Themes, working hours and reports are checked by the
+// commands using them.
+func (c *Config) Validate() error {
+	var problems []string
+	if c.Storage.Backend != BackendJSON {
+		problems = append(problems, fmt.Sprintf("storage.backend: unknown backend '%s'. Use: %s", c.Storage.Backend, BackendJSON))
+	}
+	problems = append(problems, c.problems(false)...)
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+// problems lists the invalid settings other than the storage backend. With
+// fix, each invalid value is replaced by its default, or removed if it has
+// none.
+func (c *Config) problems(fix bool) []string {
+	defaults := Default()
+	var problems []string
+	add := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	for _, group := range sortedKeys(c.Defaults.GroupValidity) {
+		v := c.Defaults.GroupValidity[group]
+		if _, err := time.ParseDuration(v); err != nil {
+			add("defaults.group_validity.%s: invalid duration '%s'", group, v)
+			if fix {
+				if d, ok := defaults.Defaults.GroupValidity[group]; ok {
+					c.Defaults.GroupValidity[group] = d
+				} else {
+					delete(c.Defaults.GroupValidity, group)
+				}
+			}
+		}
+	}
+
+	durations := []struct {
+		key   string
+		value *Duration
+		def   Duration
+	}{
+		{"pomodoro", &c.Timer.Pomodoro, defaults.Timer.Pomodoro},
+		{"session", &c.Timer.Session, defaults.Timer.Session},
+		{"work", &c.Timer.Work, defaults.Timer.Work},
+		{"break", &c.Timer.Break, defaults.Timer.Break},
+		{"flow", &c.Timer.Flow, defaults.Timer.Flow},
+	}
+	for _, d := range durations {
+		if *d.value <= 0 {
+			add("timer.%s: must be positive", d.key)
+			if fix {
+				*d.value = d.def
+			}
+		}
+	}
+	for _, name := range sortedKeys(c.Timer.Presets) {
+		if c.Timer.Presets[name] <= 0 {
+			add("timer.presets.%s: must be positive", name)
+			if fix {
+				delete(c.Timer.Presets, name)
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(c.Theme.Colors) {
+		known := false
+		for _, valid := range ColorNames {
+			known = known || name == valid
+		}
+		switch {
+		case !known:
+			add("theme.colors.%s: unknown color. Use: %s", name, strings.Join(ColorNames, ", "))
+		case !validColor(c.Theme.Colors[name]):
+			add("theme.colors.%s: invalid color '%s'. Use #RRGGBB or an ANSI number", name, c.Theme.Colors[name])
+		default:
+			continue
+		}
+		if fix {
+			delete(c.Theme.Colors, name)
+		}
+	}
+
+	if c.Notifier.Beeps < 0 {
+		add("notifier.beeps: must not be negative")
+		if fix {
+			c.Notifier.Beeps = defaults.Notifier.Beeps
+		}
+	}
+
+	for _, name := range sortedKeys(c.Flows.Browsers) {
+		if c.Flows.Browsers[name].Binary == "" {
+			add("flows.browsers.%s: binary is required", name)
+			if fix {
+				delete(c.Flows.Browsers, name)
+			}
+		}
+	}
+	return problems
+}
+
+// validColor accepts hex colors and ANSI color numbers.
+func validColor(s string) bool {
+	if hexColor.MatchString(s) {
+		return true
+	}
+	var n int
+	if _, err := fmt.Sscanf(s, "%d", &n); err != nil || fmt.Sprint(n) != s {
+		return false
+	}
+	return n >= 0 && n <= 255
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// TasksFile returns the file tasks are stored in.
+func (c *Config) TasksFile() (string, error) {
+	if c.dataErr != nil {
+		return "", fmt.Errorf("config file: %w", c.dataErr)
+	}
+	return dataFile(c.Storage.Path, "tasks.json")
+}
+
+// FlowsFile returns the file flows are stored in.
+func (c *Config) FlowsFile() (string, error) {
+	if c.dataErr != nil {
+		return "", fmt.Errorf("config file: %w", c.dataErr)
+	}
+	return dataFile(c.Flows.Path, "flows.json")
+}
+
+// dataFile expands a configured path, defaulting to name in the data
+// directory.
+func dataFile(path, name string) (string, error) {
+	if path == "" {
+		dir, err := DataDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, name), nil
+	}
+	return ExpandHome(path)
+}
+
+// ExpandHome replaces a leading ~ with the home directory.
+func ExpandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, path[1:]), nil
+}
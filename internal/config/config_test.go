@@ -0,0 +1,175 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadCurrent(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    string
+		tasksFile string // relative to the data directory
+		dataErr   bool
+		pomodoro  time.Duration
+		problems  []string
+	}{
+		{
+			name:      "no config file",
+			tasksFile: "tasks.json",
+			pomodoro:  25 * time.Minute,
+		},
+		{
+			name:      "valid",
+			config:    "storage:\n  path: elsewhere/tasks.json\ntimer:\n  pomodoro: 50m\n",
+			tasksFile: "elsewhere/tasks.json",
+			pomodoro:  50 * time.Minute,
+		},
+		{
+			name:      "invalid key keeps the storage path",
+			config:    "storage:\n  path: elsewhere/tasks.json\ntimer:\n  pomodoro: -5m\n  work: 40m\n",
+			tasksFile: "elsewhere/tasks.json",
+			pomodoro:  25 * time.Minute,
+			problems:  []string{"timer.pomodoro: must be positive", "Invalid keys use their default values"},
+		},
+		{
+			name:      "invalid color and beeps",
+			config:    "storage:\n  path: elsewhere/tasks.json\ntheme:\n  colors:\n    primary: blue\nnotifier:\n  beeps: -1\n",
+			tasksFile: "elsewhere/tasks.json",
+			pomodoro:  25 * time.Minute,
+			problems:  []string{"theme.colors.primary", "notifier.beeps"},
+		},
+		{
+			name:     "unreadable file",
+			config:   "storage:\n  path: elsewhere/tasks.json\n  bad: [\n",
+			dataErr:  true,
+			pomodoro: 25 * time.Minute,
+			problems: []string{"tasks and flows are unavailable"},
+		},
+		{
+			name:      "unknown key keeps the storage path",
+			config:    "storage:\n  path: elsewhere/tasks.json\ntimer:\n  pomodor: 50m\n",
+			tasksFile: "elsewhere/tasks.json",
+			pomodoro:  25 * time.Minute,
+			problems:  []string{"pomodor"},
+		},
+		{
+			name:     "wrong type",
+			config:   "storage:\n  path: [elsewhere]\n",
+			dataErr:  true,
+			pomodoro: 25 * time.Minute,
+			problems: []string{"tasks and flows are unavailable"},
+		},
+		{
+			name:     "unknown backend",
+			config:   "storage:\n  backend: sqlite\n  path: elsewhere/tasks.db\n",
+			dataErr:  true,
+			pomodoro: 25 * time.Minute,
+			problems: []string{"storage.backend: unknown backend 'sqlite'", "unavailable until storage.backend is fixed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv(HomeEnv, home)
+			t.Chdir(home)
+			if tt.config != "" {
+				if err := os.WriteFile(filepath.Join(home, FileName), []byte(tt.config), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cfg, err := loadCurrent()
+			if len(tt.problems) == 0 && err != nil {
+				t.Errorf("unexpected problems: %v", err)
+			}
+			for _, want := range tt.problems {
+				if err == nil || !strings.Contains(err.Error(), want) {
+					t.Errorf("problems = %v, want them to mention %q", err, want)
+				}
+			}
+			if got := time.Duration(cfg.Timer.Pomodoro); got != tt.pomodoro {
+				t.Errorf("timer.pomodoro = %s, want %s", got, tt.pomodoro)
+			}
+			if err := cfg.Validate(); err != nil && tt.name != "unknown backend" {
+				t.Errorf("settings still invalid after falling back: %v", err)
+			}
+
+			tasksFile, tasksErr := cfg.TasksFile()
+			flowsFile, flowsErr := cfg.FlowsFile()
+			if tt.dataErr {
+				if tasksErr == nil || flowsErr == nil {
+					t.Errorf("TasksFile() = %q, FlowsFile() = %q; want errors", tasksFile, flowsFile)
+				}
+				return
+			}
+			if tasksErr != nil || flowsErr != nil {
+				t.Fatalf("TasksFile: %v, FlowsFile: %v", tasksErr, flowsErr)
+			}
+			if !strings.HasSuffix(filepath.ToSlash(tasksFile), tt.tasksFile) {
+				t.Errorf("TasksFile() = %q, want %s", tasksFile, tt.tasksFile)
+			}
+		})
+	}
+}
+
+func TestMigrateContext(t *testing.T) {
+	tests := []struct {
+		name    string
+		context string
+		want    map[string]string
+	}{
+		{
+			name:    "legacy validity merged over the defaults",
+			context: `{"current_group": "work", "group_validity": {"work": "8h"}}`,
+			want:    map[string]string{"General": "24h", "work": "8h"},
+		},
+		{
+			name:    "legacy value for a default group wins",
+			context: `{"group_validity": {"General": "48h", "home": "72h"}}`,
+			want:    map[string]string{"General": "48h", "home": "72h"},
+		},
+		{
+			name:    "current group only",
+			context: `{"current_group": "work"}`,
+			want:    map[string]string{"General": "24h"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv(HomeEnv, home)
+			if err := os.WriteFile(filepath.Join(home, "context.json"), []byte(tt.context), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := Load()
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+			got := cfg.Defaults.GroupValidity
+			if len(got) != len(tt.want) {
+				t.Fatalf("group validity = %v, want %v", got, tt.want)
+			}
+			for group, v := range tt.want {
+				if got[group] != v {
+					t.Errorf("group validity = %v, want %v", got, tt.want)
+					break
+				}
+			}
+
+			saved, err := Load()
+			if err != nil {
+				t.Fatalf("Load after migration: %v", err)
+			}
+			if len(saved.Defaults.GroupValidity) != len(tt.want) {
+				t.Errorf("saved group validity = %v, want %v", saved.Defaults.GroupValidity, tt.want)
+			}
+		})
+	}
+}
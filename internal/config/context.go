@@ -6,35 +6,48 @@ import (
 	"path/filepath"
 )
 
+// HomeEnv names the environment variable that moves taskgo's data and
+// config file to a single directory.
+const HomeEnv = "TASKGO_HOME"
+
+// Context is the state that changes as taskgo is used. Settings live in the
+// config file, see Config.
 type Context struct {
-	CurrentGroup  string            `json:"current_group"`
-	GroupValidity map[string]string `json:"group_validity"`
+	CurrentGroup string `json:"current_group"`
 }
 
-func GetConfigPath() (string, error) {
+// DataDir returns the directory holding taskgo's data files: $TASKGO_HOME
+// if set, ~/.taskgo otherwise.
+func DataDir() (string, error) {
+	if dir := os.Getenv(HomeEnv); dir != "" {
+		return filepath.Abs(dir)
+	}
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(home, ".taskgo", "context.json"), nil
+	return filepath.Join(home, ".taskgo"), nil
 }
 
-func LoadContext() (*Context, error) {
-	path, err := GetConfigPath()
+// ContextPath returns the location of the context file.
+func ContextPath() (string, error) {
+	dir, err := DataDir()
 	if err != nil {
-		return nil, err
+		return "", err
 	}
+	return filepath.Join(dir, "context.json"), nil
+}
 
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		return &Context{
-			CurrentGroup: "",
-			GroupValidity: map[string]string{
-				"General": "24h",
-			},
-		}, nil
+func LoadContext() (*Context, error) {
+	path, err := ContextPath()
+	if err != nil {
+		return nil, err
 	}
 
 	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Context{}, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -48,7 +61,7 @@ func LoadContext() (*Context, error) {
 }
 
 func SaveContext(ctx *Context) error {
-	path, err := GetConfigPath()
+	path, err := ContextPath()
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,208 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Setting is a single key of the config and its value.
+type Setting struct {
+	Key   string
+	Value string
+}
+
+// Document returns the config file as a node tree, keeping its comments.
+// Without a config file the tree holds the current settings.
+func Document() (*yaml.Node, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(data)) > 0 {
+		var doc yaml.Node
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 && doc.Content[0].Kind == yaml.MappingNode {
+			return &doc, nil
+		}
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		return nil, err
+	}
+	root, err := encode(cfg)
+	if err != nil {
+		return nil, err
+	}
+	root.HeadComment = fileHeader
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}, nil
+}
+
+// Decode reads the settings of a config document.
+func Decode(doc *yaml.Node) (*Config, error) {
+	var buf bytes.Buffer
+	if err := yaml.NewEncoder(&buf).Encode(doc); err != nil {
+		return nil, err
+	}
+	return Parse(buf.Bytes())
+}
+
+// Lookup returns the value of a dotted key such as "timer.pomodoro".
+func Lookup(cfg *Config, key string) (*yaml.Node, error) {
+	node, err := encode(cfg)
+	if err != nil {
+		return nil, err
+	}
+	for _, part := range strings.Split(key, ".") {
+		if node.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("unknown key '%s'", key)
+		}
+		if node = lookup(node, part); node == nil {
+			return nil, fmt.Errorf("unknown key '%s'", key)
+		}
+	}
+	return node, nil
+}
+
+// CheckKey returns an error if key names no setting. Keys below a map,
+// such as a report name, may be anything.
+func CheckKey(key string) error {
+	t := reflect.TypeOf(Config{})
+	for _, part := range strings.Split(key, ".") {
+		switch t.Kind() {
+		case reflect.Map:
+			t = t.Elem()
+		case reflect.Struct:
+			field, ok := yamlField(t, part)
+			if !ok {
+				return fmt.Errorf("unknown key '%s'", key)
+			}
+			t = field.Type
+		default:
+			return fmt.Errorf("unknown key '%s'", key)
+		}
+	}
+	return nil
+}
+
+// yamlField finds the struct field with the given yaml name.
+func yamlField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if tag == name {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// SetKey sets a dotted key of a config document to value, which is parsed
+// as YAML. Missing sections are created.
+func SetKey(doc *yaml.Node, key, value string) error {
+	if err := CheckKey(key); err != nil {
+		return err
+	}
+
+	var parsed yaml.Node
+	if err := yaml.Unmarshal([]byte(value), &parsed); err != nil {
+		return fmt.Errorf("invalid value '%s': %w", value, err)
+	}
+	newValue := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	if len(parsed.Content) > 0 {
+		newValue = parsed.Content[0]
+	}
+
+	parts := strings.Split(key, ".")
+	node := doc.Content[0]
+	for i, part := range parts {
+		if part == "" {
+			return fmt.Errorf("invalid key '%s'", key)
+		}
+		if node.Kind != yaml.MappingNode {
+			return fmt.Errorf("'%s' is not a section", strings.Join(parts[:i], "."))
+		}
+
+		next := lookup(node, part)
+		if i == len(parts)-1 {
+			if next != nil {
+				mergeNode(next, newValue)
+			} else {
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: part}, newValue)
+			}
+			return nil
+		}
+		if next == nil || next.Tag == "!!null" {
+			section := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			if next != nil {
+				*next = *section
+				section = next
+			} else {
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: part}, section)
+			}
+			next = section
+		}
+		node = next
+	}
+	return nil
+}
+
+// List returns every key of cfg in file order. Lists and empty sections
+// are single values.
+func List(cfg *Config) ([]Setting, error) {
+	root, err := encode(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var settings []Setting
+	flatten(root, "", &settings)
+	return settings, nil
+}
+
+func flatten(node *yaml.Node, prefix string, settings *[]Setting) {
+	if node.Kind != yaml.MappingNode || len(node.Content) == 0 {
+		*settings = append(*settings, Setting{Key: prefix, Value: FormatValue(node)})
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		flatten(node.Content[i+1], key, settings)
+	}
+}
+
+// FormatValue renders a value on a single line.
+func FormatValue(node *yaml.Node) string {
+	if node.Kind == yaml.ScalarNode {
+		return node.Value
+	}
+	flow := *node
+	flow.Style = yaml.FlowStyle
+	data, err := yaml.Marshal(&flow)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func encode(cfg *Config) (*yaml.Node, error) {
+	var node yaml.Node
+	if err := node.Encode(cfg); err != nil {
+		return nil, err
+	}
+	return &node, nil
+}
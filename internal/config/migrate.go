@@ -0,0 +1,53 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// legacyContext is the context file of versions that kept the group
+// validity in it.
+type legacyContext struct {
+	CurrentGroup  string            `json:"current_group"`
+	GroupValidity map[string]string `json:"group_validity"`
+}
+
+// migrateContext moves the group validity of an old context file into a
+// new config file, leaving only the current group in the context. Without
+// it, the defaults are returned and nothing is written.
+func migrateContext() (*Config, error) {
+	cfg := Default()
+
+	path, err := ContextPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var legacy legacyContext
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return nil, err
+	}
+	if len(legacy.GroupValidity) == 0 {
+		return cfg, nil
+	}
+
+	// Groups without a legacy value keep their default validity
+	for group, validity := range legacy.GroupValidity {
+		cfg.Defaults.GroupValidity[group] = validity
+	}
+
+	if err := Save(cfg); err != nil {
+		return nil, err
+	}
+	if err := SaveContext(&Context{CurrentGroup: legacy.CurrentGroup}); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
@@ -0,0 +1,59 @@
+package editor
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Command returns the user's preferred editor from $VISUAL or $EDITOR,
+// falling back to a platform default.
+func Command() string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if e := strings.TrimSpace(os.Getenv(env)); e != "" {
+			return e
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
+
+// Edit writes content to a temporary file, opens it in the user's editor
+// and returns the saved content. pattern is passed to os.CreateTemp so the
+// file gets a meaningful extension for syntax highlighting.
+func Edit(content []byte, pattern string) ([]byte, error) {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return nil, err
+	}
+	path := f.Name()
+	defer os.Remove(path)
+
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	// $EDITOR may contain arguments, e.g. "code --wait"
+	parts := strings.Fields(Command())
+	if len(parts) == 0 {
+		return nil, errors.New("no editor configured")
+	}
+
+	cmd := exec.Command(parts[0], append(parts[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, err
+	}
+
+	return os.ReadFile(path)
+}
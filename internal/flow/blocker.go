@@ -0,0 +1,386 @@
+package flow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/MohakGupta2004/taskgo/internal/config"
+)
+
+// Markers of the section the blocker manages in the hosts file. Nothing
+// outside them is touched.
+const (
+	blockBegin = "# BEGIN taskgo distraction blocker (removed when the flow ends)"
+	blockEnd   = "# END taskgo distraction blocker"
+)
+
+// ErrDamagedSection means the markers of the managed section are missing or
+// out of order, so the section cannot be told apart from the rest of the
+// hosts file
+var ErrDamagedSection = errors.New("the taskgo section of the hosts file is damaged")
+
+// DefaultHostsFile returns the system hosts file
+func DefaultHostsFile() string {
+	if runtime.GOOS == "windows" {
+		root := os.Getenv("SystemRoot")
+		if root == "" {
+			root = `C:\Windows`
+		}
+		return filepath.Join(root, "System32", "drivers", "etc", "hosts")
+	}
+	return "/etc/hosts"
+}
+
+// Block is an active distraction block. It is recorded on disk so a block
+// left behind by a run that died can be lifted later.
+type Block struct {
+	HostsFile string    `json:"hosts_file"`
+	Flow      string    `json:"flow"`
+	PID       int       `json:"pid"`
+	Domains   []string  `json:"domains"`
+	Started   time.Time `json:"started"`
+}
+
+// NormalizeDomain turns a URL or host name into a bare lowercase domain
+// without www., which is blocked along with it
+func NormalizeDomain(s string) (string, error) {
+	host := strings.ToLower(strings.TrimSpace(s))
+	if strings.Contains(host, "://") {
+		u, err := url.Parse(host)
+		if err != nil {
+			return "", err
+		}
+		host = u.Hostname()
+	}
+	host, _, _ = strings.Cut(host, "/")
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "www."), ".")
+
+	if host == "" || !strings.Contains(host, ".") {
+		return "", fmt.Errorf("invalid domain '%s'", s)
+	}
+	for _, r := range host {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '.' || r == '-') {
+			return "", fmt.Errorf("invalid domain '%s'", s)
+		}
+	}
+	return host, nil
+}
+
+// blockedHosts adds the www. variant of every domain
+func blockedHosts(domains []string) []string {
+	var hosts []string
+	for _, d := range domains {
+		hosts = append(hosts, d, "www."+d)
+	}
+	return hosts
+}
+
+// stripBlockSection removes the managed section from hosts file contents.
+// It returns ErrDamagedSection instead of guessing where a section without
+// both markers ends.
+func stripBlockSection(content string) (string, bool, error) {
+	lines := strings.SplitAfter(content, "\n")
+	var kept []string
+	inside, found := false, false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == blockBegin:
+			if inside {
+				return "", false, ErrDamagedSection
+			}
+			inside, found = true, true
+		case trimmed == blockEnd:
+			if !inside {
+				return "", false, ErrDamagedSection
+			}
+			inside = false
+		case !inside:
+			kept = append(kept, line)
+		}
+	}
+	if inside {
+		return "", false, ErrDamagedSection
+	}
+	return strings.Join(kept, ""), found, nil
+}
+
+// writeHostsFile replaces the contents of the hosts file. The new contents
+// go to a temporary file that is renamed over it with the same owner and
+// permissions, so a crash never leaves it half written. Where that is not
+// possible, e.g. a hosts file bind-mounted into a container, the file is
+// rewritten in place and synced to disk.
+func writeHostsFile(path, content string) error {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".taskgo-hosts-*")
+	if err != nil {
+		return writeSynced(path, content)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+		return err
+	}
+	keepOwner(tmp.Name(), info)
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return writeSynced(path, content)
+	}
+	return nil
+}
+
+// writeSynced replaces the contents of path in place, creating it if
+// needed, and waits until they are on disk
+func writeSynced(path, content string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(content); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func hostsBackupPath() (string, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "hosts.backup"), nil
+}
+
+// saveHostsBackup keeps the hosts file as it was before the block, next to
+// the block record
+func saveHostsBackup(content string) error {
+	path, err := hostsBackupPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return writeSynced(path, content)
+}
+
+// loadHostsBackup returns the saved hosts file, or false if there is none
+func loadHostsBackup() (string, bool, error) {
+	path, err := hostsBackupPath()
+	if err != nil {
+		return "", false, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return string(data), true, nil
+}
+
+func removeHostsBackup() error {
+	path, err := hostsBackupPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// ApplyBlock points the domains at 0.0.0.0 in the hosts file for as long
+// as the process pid runs and records the block. The hosts file is backed
+// up first; a hosts file with a damaged section is left alone.
+func ApplyBlock(hostsFile, flow string, domains []string, pid int) (*Block, error) {
+	data, err := os.ReadFile(hostsFile)
+	if err != nil {
+		return nil, err
+	}
+	content, _, err := stripBlockSection(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", hostsFile, err)
+	}
+	original := content
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+
+	var section strings.Builder
+	section.WriteString(blockBegin + "\n")
+	for _, host := range blockedHosts(domains) {
+		fmt.Fprintf(&section, "0.0.0.0 %s\n", host)
+		fmt.Fprintf(&section, ":: %s\n", host)
+	}
+	section.WriteString(blockEnd + "\n")
+
+	b := &Block{HostsFile: hostsFile, Flow: flow, PID: pid, Domains: domains, Started: time.Now()}
+	// Record the block first so a crash while writing can still be undone
+	if err := saveBlock(b); err != nil {
+		return nil, err
+	}
+	if err := saveHostsBackup(original); err != nil {
+		removeBlockRecord()
+		return nil, err
+	}
+	if err := writeHostsFile(hostsFile, content+section.String()); err != nil {
+		removeHostsBackup()
+		removeBlockRecord()
+		return nil, err
+	}
+	return b, nil
+}
+
+// Lift removes the managed section from the hosts file and forgets the
+// block. A damaged section is undone by restoring the backup taken when the
+// block was applied.
+func (b *Block) Lift() error {
+	data, err := os.ReadFile(b.HostsFile)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err == nil {
+		backup, hasBackup, err := loadHostsBackup()
+		if err != nil {
+			return err
+		}
+
+		content, found, err := stripBlockSection(string(data))
+		switch {
+		case err != nil && !hasBackup:
+			return fmt.Errorf("%s: %w and there is no backup to restore; remove the lines between the taskgo markers by hand", b.HostsFile, err)
+		case err != nil:
+			content, found = backup, true
+		case hasBackup && content == backup+"\n":
+			// Only the newline added before the section is left over
+			content = backup
+		}
+		if found {
+			if err := writeHostsFile(b.HostsFile, content); err != nil {
+				return err
+			}
+		}
+	}
+	if err := removeHostsBackup(); err != nil {
+		return err
+	}
+	return removeBlockRecord()
+}
+
+// Orphaned reports whether the run that applied the block has died
+func (b *Block) Orphaned() bool {
+	return !processAlive(b.PID)
+}
+
+func blockPath() (string, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "blocker.json"), nil
+}
+
+// LoadBlock returns the recorded block, or nil if there is none
+func LoadBlock() (*Block, error) {
+	path, err := blockPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var b Block
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func saveBlock(b *Block) error {
+	path, err := blockPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func removeBlockRecord() error {
+	path, err := blockPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// RecoverBlock lifts a block whose run has died. It returns the lifted
+// block, or nil if there was nothing to do.
+func RecoverBlock() (*Block, error) {
+	b, err := LoadBlock()
+	if err != nil || b == nil || !b.Orphaned() {
+		return nil, err
+	}
+	return b, b.Lift()
+}
+
+// WatchBlock waits for the process pid to exit and then lifts its block if
+// it is still in place. It runs in a separate watchdog process so the block
+// is rolled back even if taskgo is killed.
+func WatchBlock(pid int) error {
+	for processAlive(pid) {
+		time.Sleep(time.Second)
+	}
+
+	b, err := LoadBlock()
+	if err != nil || b == nil || b.PID != pid {
+		return err
+	}
+	return b.Lift()
+}
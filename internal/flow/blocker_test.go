@@ -0,0 +1,148 @@
+package flow
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStripBlockSection(t *testing.T) {
+	section := blockBegin + "\n0.0.0.0 youtube.com\n:: youtube.com\n" + blockEnd + "\n"
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		found   bool
+		damaged bool
+	}{
+		{name: "no section", content: "127.0.0.1 localhost\n", want: "127.0.0.1 localhost\n"},
+		{name: "empty file", content: "", want: ""},
+		{name: "section at the end", content: "127.0.0.1 localhost\n" + section, want: "127.0.0.1 localhost\n", found: true},
+		{name: "section in the middle", content: "127.0.0.1 localhost\n" + section + "10.0.0.2 nas\n", want: "127.0.0.1 localhost\n10.0.0.2 nas\n", found: true},
+		{name: "only the section", content: section, want: "", found: true},
+		{name: "indented markers", content: "  " + blockBegin + "\n0.0.0.0 x.com\n\t" + blockEnd + "\n", want: "", found: true},
+		{name: "CRLF line endings", content: "127.0.0.1 localhost\r\n" + blockBegin + "\r\n0.0.0.0 x.com\r\n" + blockEnd + "\r\n", want: "127.0.0.1 localhost\r\n", found: true},
+		{name: "no trailing newline", content: "127.0.0.1 localhost", want: "127.0.0.1 localhost"},
+		{name: "no trailing newline after the section", content: "127.0.0.1 localhost\n" + strings.TrimSuffix(section, "\n"), want: "127.0.0.1 localhost\n", found: true},
+		{name: "no trailing newline after the section and text", content: section + "10.0.0.2 nas", want: "10.0.0.2 nas", found: true},
+		{name: "missing END marker", content: "127.0.0.1 localhost\n" + blockBegin + "\n0.0.0.0 x.com\n10.0.0.2 nas\n", damaged: true},
+		{name: "missing BEGIN marker", content: "127.0.0.1 localhost\n0.0.0.0 x.com\n" + blockEnd + "\n", damaged: true},
+		{name: "nested BEGIN marker", content: blockBegin + "\n" + section, damaged: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found, err := stripBlockSection(tt.content)
+			if tt.damaged {
+				if !errors.Is(err, ErrDamagedSection) {
+					t.Fatalf("stripBlockSection(%q) error = %v, want ErrDamagedSection", tt.content, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("stripBlockSection(%q): %v", tt.content, err)
+			}
+			if got != tt.want || found != tt.found {
+				t.Errorf("stripBlockSection(%q) = %q, %v; want %q, %v", tt.content, got, found, tt.want, tt.found)
+			}
+		})
+	}
+}
+
+func TestBlockRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		original string
+		damage   func(string) string
+	}{
+		{name: "trailing newline", original: "127.0.0.1 localhost\n::1 localhost\n"},
+		{name: "no trailing newline", original: "127.0.0.1 localhost\n::1 localhost"},
+		{name: "empty file", original: ""},
+		{
+			name:     "END marker removed",
+			original: "127.0.0.1 localhost\n",
+			damage:   func(s string) string { return strings.Replace(s, blockEnd+"\n", "", 1) },
+		},
+		{
+			name:     "BEGIN marker edited",
+			original: "127.0.0.1 localhost",
+			damage:   func(s string) string { return strings.Replace(s, blockBegin, "# taskgo", 1) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TASKGO_HOME", t.TempDir())
+			hosts := filepath.Join(t.TempDir(), "hosts")
+			if err := os.WriteFile(hosts, []byte(tt.original), 0640); err != nil {
+				t.Fatal(err)
+			}
+
+			b, err := ApplyBlock(hosts, "coding", []string{"youtube.com"}, os.Getpid())
+			if err != nil {
+				t.Fatalf("ApplyBlock: %v", err)
+			}
+			data, err := os.ReadFile(hosts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			blocked := string(data)
+			if !strings.HasPrefix(blocked, tt.original) || !strings.Contains(blocked, "0.0.0.0 www.youtube.com\n") {
+				t.Fatalf("blocked hosts file = %q", blocked)
+			}
+			if info, err := os.Stat(hosts); err != nil || info.Mode().Perm() != 0640 {
+				t.Errorf("hosts file mode changed: %v, %v", info.Mode(), err)
+			}
+
+			if tt.damage != nil {
+				if err := os.WriteFile(hosts, []byte(tt.damage(blocked)), 0640); err != nil {
+					t.Fatal(err)
+				}
+				if _, err := ApplyBlock(hosts, "coding", []string{"reddit.com"}, os.Getpid()); !errors.Is(err, ErrDamagedSection) {
+					t.Errorf("ApplyBlock on a damaged section: error = %v, want ErrDamagedSection", err)
+				}
+			}
+
+			if err := b.Lift(); err != nil {
+				t.Fatalf("Lift: %v", err)
+			}
+			data, err = os.ReadFile(hosts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.original {
+				t.Errorf("hosts file after Lift = %q, want %q", data, tt.original)
+			}
+			if _, ok, _ := loadHostsBackup(); ok {
+				t.Errorf("backup left behind after Lift")
+			}
+			if block, _ := LoadBlock(); block != nil {
+				t.Errorf("block record left behind after Lift")
+			}
+		})
+	}
+}
+
+func TestLiftDamagedWithoutBackup(t *testing.T) {
+	t.Setenv("TASKGO_HOME", t.TempDir())
+	hosts := filepath.Join(t.TempDir(), "hosts")
+	content := "127.0.0.1 localhost\n" + blockBegin + "\n0.0.0.0 x.com\n"
+	if err := os.WriteFile(hosts, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	b := &Block{HostsFile: hosts}
+	if err := b.Lift(); !errors.Is(err, ErrDamagedSection) {
+		t.Fatalf("Lift error = %v, want ErrDamagedSection", err)
+	}
+	data, err := os.ReadFile(hosts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != content {
+		t.Errorf("Lift changed a damaged hosts file without a backup: %q", data)
+	}
+}
@@ -0,0 +1,279 @@
+package flow
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/MohakGupta2004/taskgo/internal/config"
+)
+
+var (
+	chromiumFlags = config.Browser{
+		NewWindow:   []string{"--new-window"},
+		Kiosk:       []string{"--start-fullscreen"},
+		ProfileArgs: []string{"--user-data-dir={profile}"},
+	}
+	firefoxFlags = config.Browser{
+		NewWindow:   []string{"--new-window"},
+		ProfileArgs: []string{"--profile", "{profile}"},
+	}
+)
+
+// builtinBrowsers are known without configuration, tried in this order
+// when the system default browser cannot be found
+var builtinBrowsers = []string{"google-chrome", "chromium", "brave-browser", "microsoft-edge", "firefox"}
+
+// Browsers returns the browser registry: the built-in browsers overridden
+// and extended by the configured ones
+func Browsers(configured map[string]config.Browser) map[string]config.Browser {
+	browsers := make(map[string]config.Browser)
+	for _, name := range builtinBrowsers {
+		browsers[name] = browserFlags(name, []string{name})
+	}
+	for name, b := range configured {
+		browsers[name] = b
+	}
+	return browsers
+}
+
+// BrowserNames returns the registry's names in alphabetical order
+func BrowserNames(browsers map[string]config.Browser) []string {
+	names := make([]string, 0, len(browsers))
+	for name := range browsers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolveBrowser finds the browser to open a flow's URLs with. name is a
+// registry entry or a program; without one the system default browser is
+// used, then the first installed built-in browser.
+func ResolveBrowser(name string, configured map[string]config.Browser) (config.Browser, error) {
+	browsers := Browsers(configured)
+	if name != "" {
+		if b, ok := browsers[name]; ok {
+			return b, nil
+		}
+		if _, err := exec.LookPath(name); err == nil {
+			return browserFor([]string{name}, browsers), nil
+		}
+		return config.Browser{}, fmt.Errorf("unknown browser '%s'", name)
+	}
+
+	if argv, err := DefaultBrowser(); err == nil {
+		if _, err := exec.LookPath(argv[0]); err == nil {
+			return browserFor(argv, browsers), nil
+		}
+	}
+
+	for _, name := range builtinBrowsers {
+		b := browsers[name]
+		if _, err := exec.LookPath(b.Binary); err == nil {
+			return b, nil
+		}
+	}
+	return config.Browser{}, fmt.Errorf("could not find a supported browser")
+}
+
+// browserFor uses the flags of the registry entry running the same
+// program as argv, or guesses them from the program's name
+func browserFor(argv []string, browsers map[string]config.Browser) config.Browser {
+	base := filepath.Base(argv[0])
+	for _, name := range BrowserNames(browsers) {
+		b := browsers[name]
+		if name == base || filepath.Base(b.Binary) == base {
+			b.Binary = argv[0]
+			b.Args = argv[1:]
+			return b
+		}
+	}
+	return browserFlags(argv[0], argv)
+}
+
+// browserFlags guesses the flags of a browser from its command line
+func browserFlags(binary string, argv []string) config.Browser {
+	b := chromiumFlags
+	if strings.Contains(strings.ToLower(strings.Join(argv, " ")), "firefox") {
+		b = firefoxFlags
+	}
+	b.Binary = binary
+	b.Args = argv[1:]
+	return b
+}
+
+// BrowserInstalled reports whether the browser's program can be found
+func BrowserInstalled(b config.Browser) bool {
+	_, err := exec.LookPath(expandHome(b.Binary))
+	return err == nil
+}
+
+// BrowserCommand builds the command opening urls in a new window. kiosk
+// adds the browser's kiosk or fullscreen flags.
+func BrowserCommand(b config.Browser, urls []string, kiosk bool) (*exec.Cmd, error) {
+	path, err := exec.LookPath(expandHome(b.Binary))
+	if err != nil {
+		return nil, fmt.Errorf("could not find browser: %s", b.Binary)
+	}
+
+	args := append([]string{}, b.Args...)
+	args = append(args, b.NewWindow...)
+	if kiosk {
+		args = append(args, b.Kiosk...)
+	}
+	if b.Profile != "" {
+		profile := expandHome(b.Profile)
+		for _, arg := range b.ProfileArgs {
+			args = append(args, strings.ReplaceAll(arg, "{profile}", profile))
+		}
+	}
+	args = append(args, urls...)
+	return exec.Command(path, args...), nil
+}
+
+// DefaultBrowser returns the command line of the desktop's default
+// browser, read from its .desktop file. Only Linux desktops are supported.
+func DefaultBrowser() ([]string, error) {
+	if runtime.GOOS != "linux" {
+		return nil, fmt.Errorf("default browser detection is not supported on %s", runtime.GOOS)
+	}
+	out, err := exec.Command("xdg-settings", "get", "default-web-browser").Output()
+	if err != nil {
+		return nil, err
+	}
+	return DesktopExec(strings.TrimSpace(string(out)))
+}
+
+// DesktopExec finds a desktop entry such as "firefox.desktop" in the XDG
+// application directories and returns its Exec command line without field
+// codes
+func DesktopExec(id string) ([]string, error) {
+	if !strings.HasSuffix(id, ".desktop") {
+		id += ".desktop"
+	}
+
+	for _, dir := range applicationDirs() {
+		f, err := os.Open(filepath.Join(dir, id))
+		if err != nil {
+			continue
+		}
+		line, err := desktopExecLine(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", id, err)
+		}
+		argv := splitExec(line)
+		if len(argv) == 0 {
+			return nil, fmt.Errorf("%s: empty Exec line", id)
+		}
+		return argv, nil
+	}
+	return nil, fmt.Errorf("desktop entry %s not found", id)
+}
+
+// applicationDirs lists where desktop entries are installed, most specific
+// first
+func applicationDirs() []string {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		dataHome = expandHome("~/.local/share")
+	}
+	dataDirs := os.Getenv("XDG_DATA_DIRS")
+	if dataDirs == "" {
+		dataDirs = "/usr/local/share:/usr/share"
+	}
+
+	dirs := []string{filepath.Join(dataHome, "applications")}
+	for _, dir := range filepath.SplitList(dataDirs) {
+		dirs = append(dirs, filepath.Join(dir, "applications"))
+	}
+	return append(dirs,
+		expandHome("~/.local/share/flatpak/exports/share/applications"),
+		"/var/lib/flatpak/exports/share/applications",
+		"/var/lib/snapd/desktop/applications")
+}
+
+// desktopExecLine returns the Exec key of the [Desktop Entry] group
+func desktopExecLine(f *os.File) (string, error) {
+	scanner := bufio.NewScanner(f)
+	inEntry := false
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			inEntry = line == "[Desktop Entry]"
+			continue
+		}
+		if inEntry && strings.HasPrefix(line, "Exec=") {
+			return strings.TrimPrefix(line, "Exec="), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("no Exec line")
+}
+
+// splitExec splits a desktop Exec value into arguments, honouring double
+// quotes and dropping field codes such as %u. Arguments that consisted only
+// of field codes are dropped too.
+func splitExec(line string) []string {
+	var argv []string
+	var arg strings.Builder
+	inQuotes, escaped, hasArg := false, false, false
+	flush := func() {
+		if hasArg {
+			if value, ok := stripFieldCodes(arg.String()); ok {
+				argv = append(argv, value)
+			}
+		}
+		arg.Reset()
+		hasArg = false
+	}
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			arg.WriteRune(r)
+			escaped = false
+		case r == '\\' && inQuotes:
+			escaped = true
+		case r == '"':
+			inQuotes = !inQuotes
+			hasArg = true
+		case (r == ' ' || r == '\t') && !inQuotes:
+			flush()
+		default:
+			arg.WriteRune(r)
+			hasArg = true
+		}
+	}
+	flush()
+	return argv
+}
+
+// stripFieldCodes removes the field codes of an Exec argument and turns
+// "%%" into "%". It returns false if nothing but field codes was left.
+func stripFieldCodes(value string) (string, bool) {
+	if !strings.Contains(value, "%") {
+		return value, true
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '%' || i+1 == len(value) {
+			b.WriteByte(value[i])
+			continue
+		}
+		i++
+		if value[i] == '%' {
+			b.WriteByte('%')
+		}
+	}
+	return b.String(), b.Len() > 0
+}
@@ -0,0 +1,95 @@
+package flow
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSplitExec(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want []string
+	}{
+		{name: "plain", line: "firefox", want: []string{"firefox"}},
+		{name: "field code", line: "firefox %u", want: []string{"firefox"}},
+		{name: "several field codes", line: "chromium --new-window %U %F", want: []string{"chromium", "--new-window"}},
+		{name: "extra spaces and tabs", line: "  brave\t--incognito   %u ", want: []string{"brave", "--incognito"}},
+		{name: "quoted path", line: `"/opt/My Browser/browser" --profile default %u`, want: []string{"/opt/My Browser/browser", "--profile", "default"}},
+		{name: "escaped quote", line: `sh -c "echo \"hi\" && exec firefox" %u`, want: []string{"sh", "-c", `echo "hi" && exec firefox`}},
+		{name: "escaped backslash", line: `app "C:\\path"`, want: []string{"app", `C:\path`}},
+		{name: "quoted empty argument", line: `app "" --flag`, want: []string{"app", "", "--flag"}},
+		{name: "literal percent", line: "app --zoom=100%%", want: []string{"app", "--zoom=100%"}},
+		{name: "lone literal percent", line: "printf %%", want: []string{"printf", "%"}},
+		{name: "embedded field code", line: "app --url=%u --title=x", want: []string{"app", "--url=", "--title=x"}},
+		{name: "quoted field code", line: `app "%f"`, want: []string{"app"}},
+		{name: "trailing percent", line: "app 50%", want: []string{"app", "50%"}},
+		{name: "empty", line: "", want: nil},
+		{name: "only field codes", line: "%u %F", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitExec(tt.line); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitExec(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDesktopExec(t *testing.T) {
+	dataHome := t.TempDir()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_DATA_HOME", dataHome)
+	t.Setenv("XDG_DATA_DIRS", filepath.Join(t.TempDir(), "none"))
+
+	apps := filepath.Join(dataHome, "applications")
+	if err := os.MkdirAll(apps, 0755); err != nil {
+		t.Fatal(err)
+	}
+	entries := map[string]string{
+		"taskgo-test-quoted.desktop": "[Desktop Entry]\nName=Quoted\nExec=\"/opt/My Browser/browser\" --class=\"my browser\" %U\n",
+		"taskgo-test-actions.desktop": "[Desktop Action new-window]\nExec=browser --new-window %u\n\n" +
+			"[Desktop Entry]\nName=Actions\nExec=browser %u\n",
+		"taskgo-test-noexec.desktop": "[Desktop Entry]\nName=No exec\n",
+		"taskgo-test-empty.desktop":  "[Desktop Entry]\nExec=%u\n",
+	}
+	for name, content := range entries {
+		if err := os.WriteFile(filepath.Join(apps, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		id      string
+		want    []string
+		wantErr bool
+	}{
+		{id: "taskgo-test-quoted.desktop", want: []string{"/opt/My Browser/browser", "--class=my browser"}},
+		{id: "taskgo-test-quoted", want: []string{"/opt/My Browser/browser", "--class=my browser"}},
+		{id: "taskgo-test-actions.desktop", want: []string{"browser"}},
+		{id: "taskgo-test-noexec.desktop", wantErr: true},
+		{id: "taskgo-test-empty.desktop", wantErr: true},
+		{id: "taskgo-test-missing.desktop", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			got, err := DesktopExec(tt.id)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("DesktopExec(%q) = %q, want an error", tt.id, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DesktopExec(%q): %v", tt.id, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DesktopExec(%q) = %q, want %q", tt.id, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,358 @@
+package flow
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/MohakGupta2004/taskgo/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+// DocumentVersion is the version of the flow YAML schema written by Export
+const DocumentVersion = 1
+
+// ProjectFlowsDir is where a project keeps its shared flows, relative to
+// the project directory
+var ProjectFlowsDir = filepath.Join(".taskgo", "flows")
+
+// Document is the YAML form of a flow. Durations are written as Go
+// durations ("50m") and every string may use the placeholders of Params.
+type Document struct {
+	Version   int                `yaml:"version"`
+	Name      string             `yaml:"name"`
+	Group     string             `yaml:"group,omitempty"`
+	Browser   string             `yaml:"browser,omitempty"`
+	WorkDir   string             `yaml:"workdir,omitempty"`
+	Env       map[string]string  `yaml:"env,omitempty"`
+	OnFailure string             `yaml:"on_failure,omitempty"`
+	Plan      *PlanDocument      `yaml:"plan,omitempty"`
+	Resources []ResourceDocument `yaml:"resources"`
+	PreRun    []string           `yaml:"pre_run,omitempty"`
+	PostRun   []string           `yaml:"post_run,omitempty"`
+	Teardown  []string           `yaml:"teardown,omitempty"`
+	Block     []string           `yaml:"block,omitempty"`
+}
+
+// PlanDocument is the YAML form of a SessionPlan
+type PlanDocument struct {
+	Work     string `yaml:"work,omitempty"`
+	Break    string `yaml:"break,omitempty"`
+	Duration string `yaml:"duration,omitempty"`
+}
+
+// ResourceDocument is the YAML form of a Resource. A plain string is
+// accepted too and its type guessed.
+type ResourceDocument struct {
+	Type   string   `yaml:"type,omitempty"`
+	Target string   `yaml:"target"`
+	Args   []string `yaml:"args,omitempty"`
+}
+
+// UnmarshalYAML accepts both a mapping and a plain string
+func (r *ResourceDocument) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		r.Target = node.Value
+		return nil
+	}
+
+	type plain ResourceDocument
+	var p plain
+	if err := node.Decode(&p); err != nil {
+		return err
+	}
+	*r = ResourceDocument(p)
+	return nil
+}
+
+// Params are the values placeholders such as {{.ProjectDir}} expand to
+type Params struct {
+	// ProjectDir is the directory the flow belongs to
+	ProjectDir string
+	Home       string
+}
+
+// placeholderPattern matches the placeholders of Params. Other {{...}}
+// text, such as a docker --format template, is not a placeholder and is
+// kept as written.
+var placeholderPattern = regexp.MustCompile(`\{\{\s*\.(ProjectDir|Home)\s*\}\}`)
+
+// expand fills in the placeholders of s
+func (p Params) expand(s string) string {
+	return placeholderPattern.ReplaceAllStringFunc(s, func(match string) string {
+		if strings.Contains(match, "ProjectDir") {
+			return p.ProjectDir
+		}
+		return p.Home
+	})
+}
+
+// NewParams returns the placeholder values for a project directory
+func NewParams(projectDir string) Params {
+	home, _ := os.UserHomeDir()
+	return Params{ProjectDir: projectDir, Home: home}
+}
+
+// NewDocument converts a flow to its YAML form. If projectDir is set,
+// occurrences of it are replaced with {{.ProjectDir}} so the flow works in
+// other checkouts.
+func NewDocument(f *Flow, projectDir string) Document {
+	generalize := func(s string) string {
+		return generalizePath(s, projectDir)
+	}
+	generalizeAll := func(values []string) []string {
+		var out []string
+		for _, v := range values {
+			out = append(out, generalize(v))
+		}
+		return out
+	}
+
+	d := Document{
+		Version:   DocumentVersion,
+		Name:      f.Name,
+		Group:     f.Group,
+		Browser:   f.Browser,
+		WorkDir:   generalize(f.WorkDir),
+		OnFailure: f.OnFailure,
+		PreRun:    generalizeAll(f.PreRun),
+		PostRun:   generalizeAll(f.PostRun),
+		Teardown:  generalizeAll(f.Teardown),
+		Block:     f.Block,
+		Resources: []ResourceDocument{},
+	}
+	if len(f.Env) > 0 {
+		d.Env = make(map[string]string, len(f.Env))
+		for k, v := range f.Env {
+			d.Env[k] = generalize(v)
+		}
+	}
+	if f.Plan != nil {
+		d.Plan = &PlanDocument{}
+		if f.Plan.Work > 0 {
+			d.Plan.Work = shortDuration(f.Plan.Work)
+		}
+		if f.Plan.Break > 0 {
+			d.Plan.Break = shortDuration(f.Plan.Break)
+		}
+		if f.Plan.Total > 0 {
+			d.Plan.Duration = shortDuration(f.Plan.Total)
+		}
+	}
+	for _, r := range f.Resources {
+		d.Resources = append(d.Resources, ResourceDocument{
+			Type:   string(r.Type),
+			Target: generalize(r.Target),
+			Args:   generalizeAll(r.Args),
+		})
+	}
+	return d
+}
+
+// generalizePath replaces dir in s with {{.ProjectDir}} where it names dir
+// itself or a path below it, so siblings such as /src/app-old stay as they
+// are when dir is /src/app
+func generalizePath(s string, dir string) string {
+	if dir == "" {
+		return s
+	}
+
+	var b strings.Builder
+	for {
+		i := strings.Index(s, dir)
+		if i < 0 {
+			break
+		}
+		end := i + len(dir)
+		if end == len(s) || os.IsPathSeparator(s[end]) {
+			b.WriteString(s[:i])
+			b.WriteString("{{.ProjectDir}}")
+		} else {
+			b.WriteString(s[:end])
+		}
+		s = s[end:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+// Flow validates the document and builds the flow it describes, expanding
+// placeholders with params
+func (d Document) Flow(params Params) (*Flow, error) {
+	if d.Version > DocumentVersion {
+		return nil, fmt.Errorf("unsupported version %d (newest is %d)", d.Version, DocumentVersion)
+	}
+	if d.Name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if err := validName(d.Name); err != nil {
+		return nil, err
+	}
+
+	expandAll := func(values []string) []string {
+		var out []string
+		for _, v := range values {
+			out = append(out, params.expand(v))
+		}
+		return out
+	}
+
+	f := &Flow{
+		Name:      d.Name,
+		Group:     params.expand(d.Group),
+		Browser:   params.expand(d.Browser),
+		WorkDir:   params.expand(d.WorkDir),
+		OnFailure: d.OnFailure,
+		PreRun:    expandAll(d.PreRun),
+		PostRun:   expandAll(d.PostRun),
+		Teardown:  expandAll(d.Teardown),
+		Resources: []Resource{},
+	}
+	if f.OnFailure != "" && f.OnFailure != FailureAbort && f.OnFailure != FailureContinue {
+		return nil, fmt.Errorf("on_failure: must be %s or %s", FailureAbort, FailureContinue)
+	}
+	for _, domain := range d.Block {
+		normalized, err := NormalizeDomain(domain)
+		if err != nil {
+			return nil, fmt.Errorf("block: %w", err)
+		}
+		f.Block = append(f.Block, normalized)
+	}
+	if len(d.Env) > 0 {
+		f.Env = make(map[string]string, len(d.Env))
+		for k, v := range d.Env {
+			f.Env[k] = params.expand(v)
+		}
+	}
+
+	if d.Plan != nil {
+		plan := SessionPlan{}
+		for _, field := range []struct {
+			name  string
+			value string
+			dest  *time.Duration
+		}{
+			{"plan.work", d.Plan.Work, &plan.Work},
+			{"plan.break", d.Plan.Break, &plan.Break},
+			{"plan.duration", d.Plan.Duration, &plan.Total},
+		} {
+			if field.value == "" {
+				continue
+			}
+			duration, err := time.ParseDuration(field.value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid duration '%s'", field.name, field.value)
+			}
+			*field.dest = duration
+		}
+		if err := plan.Validate(); err != nil {
+			return nil, fmt.Errorf("plan: %w", err)
+		}
+		f.Plan = &plan
+	}
+
+	for i, rd := range d.Resources {
+		field := fmt.Sprintf("resources[%d]", i)
+		target := params.expand(rd.Target)
+		if target == "" {
+			return nil, fmt.Errorf("%s: target is required", field)
+		}
+		if rd.Type == "" {
+			f.Resources = append(f.Resources, GuessResource(target))
+			continue
+		}
+		resType, err := ParseResourceType(rd.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", field, err)
+		}
+		f.Resources = append(f.Resources, Resource{Type: resType, Target: target, Args: expandAll(rd.Args)})
+	}
+
+	return f, nil
+}
+
+// Export renders a flow as YAML
+func Export(f *Flow, projectDir string) ([]byte, error) {
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(NewDocument(f, projectDir)); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), enc.Close()
+}
+
+// ParseDocument reads a flow from YAML
+func ParseDocument(data []byte, params Params) (*Flow, error) {
+	var d Document
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&d); err != nil {
+		return nil, err
+	}
+	return d.Flow(params)
+}
+
+// LoadFile reads a flow from a YAML file
+func LoadFile(path string, params Params) (*Flow, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := ParseDocument(data, params)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return f, nil
+}
+
+// FlowFiles lists the YAML files of a directory, or returns path itself if
+// it is a file
+func FlowFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	for _, pattern := range []string{"*.yaml", "*.yml"} {
+		matches, err := filepath.Glob(filepath.Join(path, pattern))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+	return files, nil
+}
+
+// FindProject walks up from dir to the nearest directory with a
+// .taskgo/flows folder. taskgo's own data directory does not count.
+func FindProject(dir string) (string, bool) {
+	dataDir, _ := config.DataDir()
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+
+	for {
+		flowsDir := filepath.Join(dir, ProjectFlowsDir)
+		if filepath.Join(dir, ".taskgo") != dataDir {
+			if info, err := os.Stat(flowsDir); err == nil && info.IsDir() {
+				return dir, true
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,96 @@
+package flow
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParamsExpand(t *testing.T) {
+	params := Params{ProjectDir: "/src/app", Home: "/home/me"}
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "plain", want: "plain"},
+		{in: "{{.ProjectDir}}/NOTES.md", want: "/src/app/NOTES.md"},
+		{in: "{{ .ProjectDir }} and {{.Home}}", want: "/src/app and /home/me"},
+		{in: "docker ps --format '{{.Names}}'", want: "docker ps --format '{{.Names}}'"},
+		{in: "docker inspect -f '{{json .State}}' {{.ProjectDir}}", want: "docker inspect -f '{{json .State}}' /src/app"},
+		{in: "echo {{ unbalanced", want: "echo {{ unbalanced"},
+		{in: "{{.projectdir}}", want: "{{.projectdir}}"},
+	}
+
+	for _, tt := range tests {
+		if got := params.expand(tt.in); got != tt.want {
+			t.Errorf("expand(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestExportRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		flow *Flow
+	}{
+		{
+			name: "template braces in commands",
+			flow: &Flow{
+				Name:      "docker",
+				PreRun:    []string{"docker ps --format '{{.Names}}'"},
+				Teardown:  []string{"docker inspect -f '{{.State.Status}}' db"},
+				Env:       map[string]string{"FORMAT": "{{.ID}}"},
+				Resources: []Resource{{Type: TypeShell, Target: "watch docker ps --format '{{.Names}}'"}},
+			},
+		},
+		{
+			name: "project paths",
+			flow: &Flow{
+				Name:      "coding",
+				WorkDir:   "/src/app",
+				PreRun:    []string{"make -C /src/app/api"},
+				Resources: []Resource{{Type: TypeFile, Target: "/src/app/NOTES.md"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := Export(tt.flow, "/src/app")
+			if err != nil {
+				t.Fatalf("Export: %v", err)
+			}
+			got, err := ParseDocument(data, Params{ProjectDir: "/src/app"})
+			if err != nil {
+				t.Fatalf("ParseDocument:\n%s\n%v", data, err)
+			}
+			if !reflect.DeepEqual(got, tt.flow) {
+				t.Errorf("round trip of\n%s\ngave %+v, want %+v", data, got, tt.flow)
+			}
+		})
+	}
+}
+
+func TestGeneralizePath(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "/src/app", want: "{{.ProjectDir}}"},
+		{in: "/src/app/NOTES.md", want: "{{.ProjectDir}}/NOTES.md"},
+		{in: "/src/app-old/NOTES.md", want: "/src/app-old/NOTES.md"},
+		{in: "/src/apps", want: "/src/apps"},
+		{in: "diff /src/app-old/a /src/app/a", want: "diff /src/app-old/a {{.ProjectDir}}/a"},
+		{in: "cp /src/app/a /src/app/b", want: "cp {{.ProjectDir}}/a {{.ProjectDir}}/b"},
+		{in: "unrelated", want: "unrelated"},
+	}
+
+	for _, tt := range tests {
+		if got := generalizePath(tt.in, "/src/app"); got != tt.want {
+			t.Errorf("generalizePath(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+	if got := generalizePath("/src/app", ""); got != "/src/app" {
+		t.Errorf("generalizePath with no project = %q", got)
+	}
+}
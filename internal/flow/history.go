@@ -0,0 +1,79 @@
+package flow
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/MohakGupta2004/taskgo/internal/config"
+	"github.com/MohakGupta2004/taskgo/internal/timer"
+)
+
+// Run is a finished flow session kept in the flow history
+type Run struct {
+	Flow  string    `json:"flow"`
+	Group string    `json:"group,omitempty"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	// Focused is the time spent in work intervals, without pauses
+	Focused time.Duration `json:"focused"`
+	// Completed is set when every timer ran out rather than being quit
+	Completed bool          `json:"completed"`
+	Pauses    []timer.Pause `json:"pauses,omitempty"`
+	Resources []string      `json:"resources,omitempty"`
+}
+
+// HistoryPath returns the location of the flow history
+func HistoryPath() (string, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "flow_history.json"), nil
+}
+
+// LoadHistory reads all recorded flow runs, oldest first
+func LoadHistory() ([]Run, error) {
+	path, err := HistoryPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return []Run{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var runs []Run
+	if err := json.Unmarshal(data, &runs); err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// AppendHistory adds a run to the flow history
+func AppendHistory(r Run) error {
+	runs, err := LoadHistory()
+	if err != nil {
+		return err
+	}
+	runs = append(runs, r)
+
+	path, err := HistoryPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(runs, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
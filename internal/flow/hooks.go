@@ -0,0 +1,138 @@
+package flow
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MohakGupta2004/taskgo/internal/config"
+)
+
+// Stage names a list of shell commands run at a point of a flow's life
+type Stage string
+
+const (
+	StagePreRun   Stage = "pre-run"
+	StagePostRun  Stage = "post-run"
+	StageTeardown Stage = "teardown"
+)
+
+// Failure policies for pre-run and post-run hooks
+const (
+	FailureAbort    = "abort"
+	FailureContinue = "continue"
+)
+
+// Commands returns the command list of a stage
+func (f *Flow) Commands(stage Stage) *[]string {
+	switch stage {
+	case StagePreRun:
+		return &f.PreRun
+	case StagePostRun:
+		return &f.PostRun
+	case StageTeardown:
+		return &f.Teardown
+	}
+	return nil
+}
+
+// AbortOnFailure reports whether a failing hook stops the remaining ones
+func (f *Flow) AbortOnFailure() bool {
+	return f.OnFailure != FailureContinue
+}
+
+// Environ returns the environment for commands started by the flow: the
+// current environment with the flow's variables added
+func (f *Flow) Environ() []string {
+	env := os.Environ()
+	keys := make([]string, 0, len(f.Env))
+	for k := range f.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		env = append(env, k+"="+f.Env[k])
+	}
+	return env
+}
+
+// prepare applies the flow's environment and working directory to cmd.
+// A directory already set on cmd, e.g. for a terminal, is kept.
+func (f *Flow) prepare(cmd *exec.Cmd) {
+	cmd.Env = f.Environ()
+	if cmd.Dir == "" && f.WorkDir != "" {
+		cmd.Dir = expandHome(f.WorkDir)
+	}
+}
+
+// Launch starts a resource with the flow's environment and working
+// directory
+func (f *Flow) Launch(r Resource) (Process, error) {
+	cmd, err := r.Command()
+	if err != nil {
+		return Process{}, err
+	}
+	f.prepare(cmd)
+	return Start(cmd, r.String())
+}
+
+// RunHooks runs the commands of a stage in order, in the foreground,
+// copying their output to stdout and log. With the abort policy the first
+// failure stops the stage; teardown always runs every command. The
+// returned error reports the failed commands.
+func (f *Flow) RunHooks(stage Stage, log io.Writer) error {
+	commands := *f.Commands(stage)
+	var failed []string
+	for _, command := range commands {
+		fmt.Fprintf(log, "[%s] %s: %s\n", time.Now().Format(time.RFC3339), stage, command)
+		fmt.Printf("▶ %s: %s\n", stage, command)
+
+		cmd := (Resource{Type: TypeShell, Target: command}).shellCommand()
+		f.prepare(cmd)
+		out := io.MultiWriter(os.Stdout, log)
+		cmd.Stdout = out
+		cmd.Stderr = out
+
+		if err := cmd.Run(); err != nil {
+			fmt.Fprintf(out, "%s failed: %v\n", command, err)
+			failed = append(failed, command)
+			if stage != StageTeardown && f.AbortOnFailure() {
+				break
+			}
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%s failed: %s", stage, strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+// LogPath returns the file hook output of a flow is appended to
+func LogPath(name string) (string, error) {
+	if err := validName(name); err != nil {
+		return "", err
+	}
+	dir, err := config.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "logs", "flow-"+name+".log"), nil
+}
+
+// OpenLog opens a flow's hook log for appending
+func OpenLog(name string) (*os.File, error) {
+	path, err := LogPath(name)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+}
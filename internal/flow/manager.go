@@ -3,30 +3,79 @@ package flow
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/MohakGupta2004/taskgo/internal/config"
 )
 
 // Flow represents a focused work session configuration
 type Flow struct {
-	Name      string   `json:"name"`
-	Resources []string `json:"resources"`
+	Name      string     `json:"name"`
+	Resources []Resource `json:"resources"`
+	// PreRun and PostRun hold shell commands run before the resources are
+	// opened and after the session ends
+	PreRun  []string `json:"pre_run,omitempty"`
+	PostRun []string `json:"post_run,omitempty"`
+	// Teardown holds shell commands run when the flow is torn down
+	Teardown []string `json:"teardown,omitempty"`
+	// Env and WorkDir apply to hooks and launched resources
+	Env     map[string]string `json:"env,omitempty"`
+	WorkDir string            `json:"workdir,omitempty"`
+	// OnFailure is FailureAbort (default) or FailureContinue
+	OnFailure string `json:"on_failure,omitempty"`
+	// Group is the task group checked out while the flow runs
+	Group string `json:"group,omitempty"`
+	// Plan sets the work and break timers of a run
+	Plan *SessionPlan `json:"plan,omitempty"`
+	// Browser is the browser registry entry or program URLs are opened in
+	Browser string `json:"browser,omitempty"`
+	// Block lists domains blocked while the flow runs in Zen Mode
+	Block []string `json:"block,omitempty"`
+}
+
+// SessionPlan returns the flow's plan, or the default single block
+func (f *Flow) SessionPlan() SessionPlan {
+	if f.Plan == nil {
+		return SessionPlan{}
+	}
+	return *f.Plan
 }
 
-// Manager handles loading and saving flows
+// Manager handles loading and saving flows. Flows found in the current
+// project's .taskgo/flows folder are merged in; they are read-only, never
+// written to the user's file and never take the place of a user flow of
+// the same name.
 type Manager struct {
 	Flows map[string]*Flow `json:"flows"`
-	path  string
+	// ProjectDir is the project the project flows were found in
+	ProjectDir string `json:"-"`
+	// Problems explains why project flow files were skipped
+	Problems     []error `json:"-"`
+	path         string
+	project      map[string]*Flow
+	sources      map[string]string
+	projectFiles []string
+	projectHash  string
+	trusted      bool
 }
 
 // NewManager creates a new flow manager
 func NewManager() (*Manager, error) {
-	home, err := os.UserHomeDir()
+	path, err := config.Current().FlowsFile()
 	if err != nil {
 		return nil, err
 	}
+	if err := migrateFlowsFile(path); err != nil {
+		return nil, err
+	}
 
-	path := filepath.Join(home, ".taskgo_flows.json")
 	m := &Manager{
 		Flows: make(map[string]*Flow),
 		path:  path,
@@ -39,9 +88,146 @@ func NewManager() (*Manager, error) {
 		}
 	}
 
+	if cwd, err := os.Getwd(); err == nil {
+		m.loadProject(cwd)
+	}
+
 	return m, nil
 }
 
+// migrateFlowsFile moves the flows of older versions, which lived in
+// ~/.taskgo_flows.json, to path. Nothing is moved if $TASKGO_HOME is set
+// or path already exists.
+func migrateFlowsFile(path string) error {
+	if os.Getenv(config.HomeEnv) != "" {
+		return nil
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		return nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return err
+	}
+	legacy := filepath.Join(home, ".taskgo_flows.json")
+	data, err := os.ReadFile(legacy)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return err
+	}
+	return os.Remove(legacy)
+}
+
+// loadProject reads the flows of the project dir belongs to, if any. Files
+// that cannot be used are skipped and recorded in Problems, so a broken
+// project file never hides the user's own flows.
+func (m *Manager) loadProject(dir string) {
+	projectDir, ok := FindProject(dir)
+	if !ok {
+		return
+	}
+
+	m.ProjectDir = projectDir
+	m.project = make(map[string]*Flow)
+	m.sources = make(map[string]string)
+
+	files, err := FlowFiles(filepath.Join(projectDir, ProjectFlowsDir))
+	if err != nil {
+		m.Problems = append(m.Problems, err)
+		return
+	}
+
+	// Every file is read once, so the flows are exactly what gets trusted
+	contents := make(map[string][]byte)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			m.Problems = append(m.Problems, err)
+			continue
+		}
+		contents[file] = data
+		m.projectFiles = append(m.projectFiles, file)
+	}
+	m.projectHash = hashFiles(projectDir, m.projectFiles, contents)
+	if trusted, err := loadTrusted(); err != nil {
+		m.Problems = append(m.Problems, fmt.Errorf("reading trusted projects: %w", err))
+	} else {
+		m.trusted = trusted[projectDir] == m.projectHash
+	}
+
+	params := NewParams(projectDir)
+	for _, file := range m.projectFiles {
+		f, err := ParseDocument(contents[file], params)
+		if err != nil {
+			m.Problems = append(m.Problems, fmt.Errorf("%s: %w", file, err))
+			continue
+		}
+		if other, exists := m.sources[f.Name]; exists {
+			m.Problems = append(m.Problems, fmt.Errorf("%s: flow '%s' is already defined in %s", file, f.Name, other))
+			continue
+		}
+		if _, mine := m.Flows[f.Name]; mine {
+			m.Problems = append(m.Problems, fmt.Errorf("%s: flow '%s' has the same name as one of your flows", file, f.Name))
+			continue
+		}
+		m.project[f.Name] = f
+		m.sources[f.Name] = file
+	}
+}
+
+// Source returns the project file a flow was loaded from, or "" for the
+// user's own flows
+func (m *Manager) Source(name string) string {
+	return m.sources[name]
+}
+
+// editable returns a user flow for changing. Project flows are rejected
+// since changes to them could not be saved.
+func (m *Manager) editable(name string) (*Flow, error) {
+	if source := m.Source(name); source != "" {
+		return nil, fmt.Errorf("flow '%s' is defined in %s; edit that file or clone the flow", name, source)
+	}
+	flow, exists := m.Flows[name]
+	if !exists {
+		return nil, fmt.Errorf("flow '%s' not found", name)
+	}
+	return flow, nil
+}
+
+// validName returns an error if name cannot be used for a flow. Names end
+// up in file names, such as the hook log, so they may not contain path
+// separators, ".." or control characters.
+func validName(name string) error {
+	switch {
+	case strings.TrimSpace(name) == "":
+		return fmt.Errorf("flow name is required")
+	case strings.ContainsAny(name, `/\`):
+		return fmt.Errorf("invalid flow name %q: it may not contain / or \\", name)
+	case strings.Contains(name, ".."):
+		return fmt.Errorf("invalid flow name %q: it may not contain '..'", name)
+	case strings.ContainsFunc(name, unicode.IsControl):
+		return fmt.Errorf("invalid flow name %q: it may not contain control characters", name)
+	}
+	return nil
+}
+
+// exists reports whether a user or project flow has the name
+func (m *Manager) exists(name string) bool {
+	_, user := m.Flows[name]
+	_, project := m.project[name]
+	return user || project
+}
+
 // Load reads flows from disk
 func (m *Manager) Load() error {
 	data, err := os.ReadFile(m.path)
@@ -58,49 +244,326 @@ func (m *Manager) Save() error {
 	if err != nil {
 		return err
 	}
+	if err := os.MkdirAll(filepath.Dir(m.path), 0755); err != nil {
+		return err
+	}
 
 	return os.WriteFile(m.path, data, 0644)
 }
 
 // Create adds a new flow
 func (m *Manager) Create(name string) error {
-	if _, exists := m.Flows[name]; exists {
+	if err := validName(name); err != nil {
+		return err
+	}
+	if m.exists(name) {
 		return fmt.Errorf("flow '%s' already exists", name)
 	}
 
 	m.Flows[name] = &Flow{
 		Name:      name,
-		Resources: []string{},
+		Resources: []Resource{},
 	}
 
 	return m.Save()
 }
 
 // AddResource adds a resource to a flow
-func (m *Manager) AddResource(name string, resource string) error {
-	flow, exists := m.Flows[name]
-	if !exists {
-		return fmt.Errorf("flow '%s' not found", name)
+func (m *Manager) AddResource(name string, resource Resource) error {
+	flow, err := m.editable(name)
+	if err != nil {
+		return err
 	}
 
 	flow.Resources = append(flow.Resources, resource)
 	return m.Save()
 }
 
-// Get returns a flow by name
+// AddCommand appends a shell command to one of the flow's stages
+func (m *Manager) AddCommand(name string, stage Stage, command string) error {
+	flow, err := m.editable(name)
+	if err != nil {
+		return err
+	}
+
+	commands := flow.Commands(stage)
+	*commands = append(*commands, command)
+	return m.Save()
+}
+
+// RemoveCommand removes a command, given by 1-based position or value, from
+// one of the flow's stages and returns it
+func (m *Manager) RemoveCommand(name string, stage Stage, ref string) (string, error) {
+	flow, err := m.editable(name)
+	if err != nil {
+		return "", err
+	}
+
+	commands := flow.Commands(stage)
+	i := slices.Index(*commands, ref)
+	if n, err := strconv.Atoi(ref); err == nil {
+		i = n - 1
+	}
+	if i < 0 || i >= len(*commands) {
+		return "", fmt.Errorf("%s command '%s' not found in flow '%s'", stage, ref, name)
+	}
+
+	removed := (*commands)[i]
+	*commands = slices.Delete(*commands, i, i+1)
+	return removed, m.Save()
+}
+
+// SetEnv sets an environment variable of a flow; an empty value removes it
+func (m *Manager) SetEnv(name string, key string, value string) error {
+	flow, err := m.editable(name)
+	if err != nil {
+		return err
+	}
+
+	if value == "" {
+		delete(flow.Env, key)
+	} else {
+		if flow.Env == nil {
+			flow.Env = make(map[string]string)
+		}
+		flow.Env[key] = value
+	}
+	return m.Save()
+}
+
+// SetWorkDir sets the directory hooks and resources start in
+func (m *Manager) SetWorkDir(name string, dir string) error {
+	flow, err := m.editable(name)
+	if err != nil {
+		return err
+	}
+
+	flow.WorkDir = dir
+	return m.Save()
+}
+
+// SetGroup binds a flow to a task group; an empty group removes the binding
+func (m *Manager) SetGroup(name string, group string) error {
+	flow, err := m.editable(name)
+	if err != nil {
+		return err
+	}
+
+	flow.Group = group
+	return m.Save()
+}
+
+// SetBrowser sets the browser a flow opens its URLs in
+func (m *Manager) SetBrowser(name string, browser string) error {
+	flow, err := m.editable(name)
+	if err != nil {
+		return err
+	}
+
+	flow.Browser = browser
+	return m.Save()
+}
+
+// SetBlocked sets the domains a flow blocks
+func (m *Manager) SetBlocked(name string, domains []string) error {
+	flow, err := m.editable(name)
+	if err != nil {
+		return err
+	}
+
+	flow.Block = domains
+	return m.Save()
+}
+
+// SetPlan sets the session plan of a flow
+func (m *Manager) SetPlan(name string, plan SessionPlan) error {
+	if err := plan.Validate(); err != nil {
+		return err
+	}
+
+	flow, err := m.editable(name)
+	if err != nil {
+		return err
+	}
+
+	flow.Plan = &plan
+	return m.Save()
+}
+
+// SetFailurePolicy sets whether a failing hook aborts the stage
+func (m *Manager) SetFailurePolicy(name string, policy string) error {
+	if policy != FailureAbort && policy != FailureContinue {
+		return fmt.Errorf("invalid failure policy '%s'. Use: %s, %s", policy, FailureAbort, FailureContinue)
+	}
+
+	flow, err := m.editable(name)
+	if err != nil {
+		return err
+	}
+
+	flow.OnFailure = policy
+	return m.Save()
+}
+
+// Get returns a user or project flow by name
 func (m *Manager) Get(name string) (*Flow, error) {
-	flow, exists := m.Flows[name]
+	if flow, exists := m.Flows[name]; exists {
+		return flow, nil
+	}
+	flow, exists := m.project[name]
 	if !exists {
 		return nil, fmt.Errorf("flow '%s' not found", name)
 	}
 	return flow, nil
 }
 
-// List returns all flow names
+// List returns all flow names in alphabetical order
 func (m *Manager) List() []string {
-	keys := make([]string, 0, len(m.Flows))
+	keys := make([]string, 0, len(m.Flows)+len(m.project))
 	for k := range m.Flows {
 		keys = append(keys, k)
 	}
+	for k := range m.project {
+		if _, shadowed := m.Flows[k]; !shadowed {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
 	return keys
 }
+
+// resolveResource finds a resource by 1-based position or exact value
+func resolveResource(f *Flow, ref string) (int, error) {
+	if n, err := strconv.Atoi(ref); err == nil {
+		if n < 1 || n > len(f.Resources) {
+			return 0, fmt.Errorf("flow '%s' has no resource %d", f.Name, n)
+		}
+		return n - 1, nil
+	}
+	for i, res := range f.Resources {
+		if res.Matches(ref) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("resource '%s' not found in flow '%s'", ref, f.Name)
+}
+
+// RemoveResource removes a resource, given by position or value, and
+// returns it
+func (m *Manager) RemoveResource(name string, ref string) (Resource, error) {
+	flow, err := m.editable(name)
+	if err != nil {
+		return Resource{}, err
+	}
+
+	i, err := resolveResource(flow, ref)
+	if err != nil {
+		return Resource{}, err
+	}
+
+	removed := flow.Resources[i]
+	flow.Resources = append(flow.Resources[:i], flow.Resources[i+1:]...)
+	return removed, m.Save()
+}
+
+// MoveResource moves a resource, given by position or value, to the
+// 1-based position to
+func (m *Manager) MoveResource(name string, ref string, to int) error {
+	flow, err := m.editable(name)
+	if err != nil {
+		return err
+	}
+
+	i, err := resolveResource(flow, ref)
+	if err != nil {
+		return err
+	}
+	if to < 1 || to > len(flow.Resources) {
+		return fmt.Errorf("position %d is out of range (1-%d)", to, len(flow.Resources))
+	}
+
+	res := flow.Resources[i]
+	flow.Resources = append(flow.Resources[:i], flow.Resources[i+1:]...)
+	flow.Resources = slices.Insert(flow.Resources, to-1, res)
+	return m.Save()
+}
+
+// Rename changes the name of a flow
+func (m *Manager) Rename(oldName string, newName string) error {
+	flow, err := m.editable(oldName)
+	if err != nil {
+		return err
+	}
+	if err := validName(newName); err != nil {
+		return err
+	}
+	if m.exists(newName) {
+		return fmt.Errorf("flow '%s' already exists", newName)
+	}
+
+	delete(m.Flows, oldName)
+	flow.Name = newName
+	m.Flows[newName] = flow
+	return m.Save()
+}
+
+// Clone copies a flow and its resources under a new name. Project flows
+// are only copied once the project is trusted, since the copy runs
+// without asking.
+func (m *Manager) Clone(name string, newName string) error {
+	flow, err := m.Get(name)
+	if err != nil {
+		return err
+	}
+	if err := m.CheckTrusted(name); err != nil {
+		return err
+	}
+	if err := validName(newName); err != nil {
+		return err
+	}
+	if m.exists(newName) {
+		return fmt.Errorf("flow '%s' already exists", newName)
+	}
+
+	clone := *flow
+	clone.Name = newName
+	clone.Resources = slices.Clone(flow.Resources)
+	clone.PreRun = slices.Clone(flow.PreRun)
+	clone.PostRun = slices.Clone(flow.PostRun)
+	clone.Teardown = slices.Clone(flow.Teardown)
+	clone.Env = maps.Clone(flow.Env)
+	clone.Block = slices.Clone(flow.Block)
+	if flow.Plan != nil {
+		plan := *flow.Plan
+		clone.Plan = &plan
+	}
+	m.Flows[newName] = &clone
+	return m.Save()
+}
+
+// Import adds a flow read from a file to the user's flows, replacing an
+// existing user or project flow of the same name only if replace is set
+func (m *Manager) Import(f *Flow, replace bool) error {
+	if err := validName(f.Name); err != nil {
+		return err
+	}
+	if m.exists(f.Name) && !replace {
+		return fmt.Errorf("flow '%s' already exists", f.Name)
+	}
+
+	// The imported flow is the user's own, so it wins over the project's
+	delete(m.project, f.Name)
+	delete(m.sources, f.Name)
+	m.Flows[f.Name] = f
+	return m.Save()
+}
+
+// Delete removes a flow
+func (m *Manager) Delete(name string) error {
+	if _, err := m.editable(name); err != nil {
+		return err
+	}
+
+	delete(m.Flows, name)
+	return m.Save()
+}
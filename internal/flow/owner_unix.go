@@ -0,0 +1,16 @@
+//go:build !windows
+
+package flow
+
+import (
+	"os"
+	"syscall"
+)
+
+// keepOwner gives path the owner and group described by info where the
+// user may change them
+func keepOwner(path string, info os.FileInfo) {
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		os.Chown(path, int(st.Uid), int(st.Gid))
+	}
+}
@@ -0,0 +1,9 @@
+//go:build windows
+
+package flow
+
+import "os"
+
+// keepOwner does nothing on Windows, where a renamed file keeps the
+// permissions inherited from its directory
+func keepOwner(path string, info os.FileInfo) {}
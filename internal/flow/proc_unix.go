@@ -0,0 +1,65 @@
+//go:build !windows
+
+package flow
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+func processAlive(pid int) bool {
+	return syscall.Kill(pid, 0) == nil
+}
+
+// groupAlive reports whether any process of the group led by pid is still
+// running, even if the leader itself has exited
+func groupAlive(pid int) bool {
+	err := syscall.Kill(-pid, 0)
+	return err == nil || err == syscall.EPERM
+}
+
+// processStartTime identifies when pid started so a reused PID can be told
+// apart. It reads /proc where there is one and asks ps otherwise.
+func processStartTime(pid int) (string, error) {
+	if data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid)); err == nil {
+		// The command name may contain spaces, so fields are counted from
+		// the closing parenthesis; starttime is the 22nd field
+		stat := string(data)
+		if end := strings.LastIndexByte(stat, ')'); end >= 0 {
+			if fields := strings.Fields(stat[end+1:]); len(fields) >= 20 {
+				return fields[19], nil
+			}
+		}
+		return "", fmt.Errorf("unexpected /proc/%d/stat format", pid)
+	}
+
+	out, err := exec.Command("ps", "-o", "lstart=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func terminateGroup(pid int) error {
+	return ignoreGone(syscall.Kill(-pid, syscall.SIGTERM))
+}
+
+func killGroup(pid int) error {
+	return ignoreGone(syscall.Kill(-pid, syscall.SIGKILL))
+}
+
+// ignoreGone treats a process that has already exited as success
+func ignoreGone(err error) error {
+	if err == syscall.ESRCH {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,65 @@
+//go:build windows
+
+package flow
+
+import (
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
+
+func processAlive(pid int) bool {
+	const processQueryLimitedInformation = 0x1000
+	const stillActive = 259
+
+	h, err := syscall.OpenProcess(processQueryLimitedInformation, false, uint32(pid))
+	if err != nil {
+		return false
+	}
+	defer syscall.CloseHandle(h)
+
+	var code uint32
+	if err := syscall.GetExitCodeProcess(h, &code); err != nil {
+		return false
+	}
+	return code == stillActive
+}
+
+// groupAlive reports whether the process tree started as pid is running.
+// Windows has no process groups to query, so only the root is checked
+func groupAlive(pid int) bool {
+	return processAlive(pid)
+}
+
+// processStartTime identifies when pid started so a reused PID can be told
+// apart
+func processStartTime(pid int) (string, error) {
+	const processQueryLimitedInformation = 0x1000
+
+	h, err := syscall.OpenProcess(processQueryLimitedInformation, false, uint32(pid))
+	if err != nil {
+		return "", err
+	}
+	defer syscall.CloseHandle(h)
+
+	var created, exited, kernel, user syscall.Filetime
+	if err := syscall.GetProcessTimes(h, &created, &exited, &kernel, &user); err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(created.Nanoseconds(), 10), nil
+}
+
+// Windows has no graceful group signal for GUI apps, so terminating asks
+// taskkill to close the tree and killing forces it
+func terminateGroup(pid int) error {
+	exec.Command("taskkill", "/T", "/PID", strconv.Itoa(pid)).Run()
+	return nil
+}
+
+func killGroup(pid int) error {
+	return exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(pid)).Run()
+}
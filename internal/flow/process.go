@@ -0,0 +1,186 @@
+package flow
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/MohakGupta2004/taskgo/internal/config"
+)
+
+// terminateGrace is how long launched processes get to exit after being
+// asked to before they are killed
+const terminateGrace = 3 * time.Second
+
+// Process is a program started by a running flow. It leads its own process
+// group so everything it spawns can be stopped together.
+type Process struct {
+	PID      int    `json:"pid"`
+	Resource string `json:"resource"`
+	// StartTime is when the leader started, in a form that depends on the
+	// OS. It tells the leader apart from a later process that got its PID.
+	StartTime string `json:"start_time,omitempty"`
+}
+
+// Session records what a flow run launched so it can be torn down later,
+// even from another taskgo invocation
+type Session struct {
+	Flow      string    `json:"flow"`
+	Started   time.Time `json:"started"`
+	Processes []Process `json:"processes"`
+}
+
+// Start runs cmd in a new process group without waiting for it and reaps it
+// in the background once it exits
+func Start(cmd *exec.Cmd, resource string) (Process, error) {
+	setProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		return Process{}, err
+	}
+	p := Process{PID: cmd.Process.Pid, Resource: resource}
+	p.StartTime, _ = processStartTime(p.PID)
+	go cmd.Wait()
+	return p, nil
+}
+
+// startTimeOf reads the start time of a process; tests replace it
+var startTimeOf = processStartTime
+
+// Running reports whether anything of the process group is still running.
+// A leader that is alive but started at another time than recorded, or
+// whose start time can no longer be read, may be a different program that
+// reused the PID, so the group is treated as gone.
+func (p Process) Running() bool {
+	if !groupAlive(p.PID) {
+		return false
+	}
+	if p.StartTime == "" || !processAlive(p.PID) {
+		// Without a leader the PID cannot have been reused while its group
+		// still exists
+		return true
+	}
+	started, err := startTimeOf(p.PID)
+	return err == nil && started == p.StartTime
+}
+
+// Alive returns the processes that are still running
+func (s *Session) Alive() []Process {
+	var alive []Process
+	for _, p := range s.Processes {
+		if p.Running() {
+			alive = append(alive, p)
+		}
+	}
+	return alive
+}
+
+// Terminate asks a process group to exit and kills it if it is still
+// running after terminateGrace. Groups that have already exited or whose
+// PID now belongs to another program are left alone.
+func Terminate(p Process) error {
+	if !p.Running() {
+		return nil
+	}
+	if err := terminateGroup(p.PID); err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(terminateGrace)
+	for time.Now().Before(deadline) {
+		if !groupAlive(p.PID) {
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return killGroup(p.PID)
+}
+
+func sessionsPath() (string, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "flow_sessions.json"), nil
+}
+
+// LoadSessions returns the recorded sessions by flow name. Processes that
+// are no longer running are dropped, and so are sessions left without any.
+func LoadSessions() (map[string]*Session, error) {
+	path, err := sessionsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make(map[string]*Session)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return sessions, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &sessions); err != nil {
+		return nil, err
+	}
+
+	stale := false
+	for name, s := range sessions {
+		alive := s.Alive()
+		if len(alive) == len(s.Processes) {
+			continue
+		}
+		stale = true
+		if len(alive) == 0 {
+			delete(sessions, name)
+			continue
+		}
+		s.Processes = alive
+	}
+	if stale {
+		if err := saveSessions(sessions); err != nil {
+			return nil, err
+		}
+	}
+	return sessions, nil
+}
+
+// SaveSession records a session, replacing an earlier one of the same flow
+func SaveSession(s *Session) error {
+	sessions, err := LoadSessions()
+	if err != nil {
+		return err
+	}
+	sessions[s.Flow] = s
+	return saveSessions(sessions)
+}
+
+// RemoveSession forgets the session of a flow
+func RemoveSession(name string) error {
+	sessions, err := LoadSessions()
+	if err != nil {
+		return err
+	}
+	if _, ok := sessions[name]; !ok {
+		return nil
+	}
+	delete(sessions, name)
+	return saveSessions(sessions)
+}
+
+func saveSessions(sessions map[string]*Session) error {
+	path, err := sessionsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(sessions, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
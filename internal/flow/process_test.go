@@ -0,0 +1,84 @@
+//go:build !windows
+
+package flow
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+)
+
+func TestProcessRunning(t *testing.T) {
+	// The leader exits at once while its child keeps the group alive
+	p, err := Start(exec.Command("sh", "-c", "sleep 30 & exit 0"), "sleep")
+	if err != nil {
+		t.Skipf("cannot start sh: %v", err)
+	}
+	defer killGroup(p.PID)
+
+	if p.StartTime == "" {
+		t.Errorf("no start time recorded for pid %d", p.PID)
+	}
+
+	tests := []struct {
+		name string
+		p    Process
+		want bool
+	}{
+		{name: "recorded process", p: p, want: true},
+		{name: "without a start time", p: Process{PID: p.PID}, want: true},
+	}
+	for _, tt := range tests {
+		if got := tt.p.Running(); got != tt.want {
+			t.Errorf("%s: Running() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if err := Terminate(p); err != nil {
+		t.Fatalf("Terminate: %v", err)
+	}
+	if p.Running() {
+		t.Errorf("group %d still running after Terminate", p.PID)
+	}
+}
+
+func TestProcessRunningReusedPID(t *testing.T) {
+	p, err := Start(exec.Command("sleep", "30"), "sleep")
+	if err != nil {
+		t.Skipf("cannot start sleep: %v", err)
+	}
+	defer killGroup(p.PID)
+
+	reused := p
+	reused.StartTime = "not " + p.StartTime
+	if reused.Running() {
+		t.Errorf("process with another start time reported as running")
+	}
+	if err := Terminate(reused); err != nil {
+		t.Fatalf("Terminate: %v", err)
+	}
+	if !p.Running() {
+		t.Errorf("Terminate signalled a process whose start time did not match")
+	}
+}
+
+func TestProcessRunningUnreadableStartTime(t *testing.T) {
+	p, err := Start(exec.Command("sleep", "30"), "sleep")
+	if err != nil {
+		t.Skipf("cannot start sleep: %v", err)
+	}
+	defer killGroup(p.PID)
+
+	startTimeOf = func(int) (string, error) { return "", errors.New("no access") }
+	defer func() { startTimeOf = processStartTime }()
+
+	if p.Running() {
+		t.Errorf("process whose start time cannot be read reported as running")
+	}
+	if err := Terminate(p); err != nil {
+		t.Fatalf("Terminate: %v", err)
+	}
+	if !processAlive(p.PID) {
+		t.Errorf("Terminate signalled a process whose start time could not be read")
+	}
+}
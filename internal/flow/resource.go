@@ -0,0 +1,204 @@
+package flow
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// ResourceType tells how a resource is opened
+type ResourceType string
+
+const (
+	TypeURL      ResourceType = "url"
+	TypeApp      ResourceType = "app"
+	TypeFile     ResourceType = "file"
+	TypeDir      ResourceType = "dir"
+	TypeShell    ResourceType = "shell"
+	TypeTerminal ResourceType = "terminal"
+)
+
+// ResourceTypes lists the valid resource types
+var ResourceTypes = []ResourceType{TypeURL, TypeApp, TypeFile, TypeDir, TypeShell, TypeTerminal}
+
+// Resource is something opened when a flow runs. Target is the URL, program,
+// path or shell command depending on Type; Args only apply to apps.
+type Resource struct {
+	Type   ResourceType `json:"type"`
+	Target string       `json:"target"`
+	Args   []string     `json:"args,omitempty"`
+}
+
+// ParseResourceType validates a --type value
+func ParseResourceType(s string) (ResourceType, error) {
+	t := ResourceType(strings.ToLower(s))
+	if t == "directory" {
+		return TypeDir, nil
+	}
+	for _, valid := range ResourceTypes {
+		if t == valid {
+			return t, nil
+		}
+	}
+
+	names := make([]string, len(ResourceTypes))
+	for i, valid := range ResourceTypes {
+		names[i] = string(valid)
+	}
+	return "", fmt.Errorf("invalid resource type '%s'. Use: %s", s, strings.Join(names, ", "))
+}
+
+// GuessResource infers the type of an untyped resource string. Existing
+// paths are files or directories, things with a scheme or www. are URLs,
+// programs on the PATH are apps and other dotted names are assumed to be
+// domains.
+func GuessResource(s string) Resource {
+	if strings.Contains(s, "://") || strings.HasPrefix(s, "www.") {
+		return Resource{Type: TypeURL, Target: s}
+	}
+
+	if info, err := os.Stat(expandHome(s)); err == nil {
+		if info.IsDir() {
+			return Resource{Type: TypeDir, Target: s}
+		}
+		return Resource{Type: TypeFile, Target: s}
+	}
+
+	fields := strings.Fields(s)
+	if len(fields) > 0 {
+		if _, err := exec.LookPath(fields[0]); err == nil {
+			return Resource{Type: TypeApp, Target: fields[0], Args: fields[1:]}
+		}
+	}
+
+	if strings.Contains(s, ".") && !strings.Contains(s, " ") {
+		return Resource{Type: TypeURL, Target: s}
+	}
+	return Resource{Type: TypeApp, Target: s}
+}
+
+// UnmarshalJSON accepts both typed resources and the plain strings older
+// versions stored, guessing the type of the latter
+func (r *Resource) UnmarshalJSON(data []byte) error {
+	var legacy string
+	if err := json.Unmarshal(data, &legacy); err == nil {
+		*r = GuessResource(legacy)
+		return nil
+	}
+
+	type plain Resource
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	*r = Resource(p)
+	return nil
+}
+
+// String returns the resource as it would be typed on the command line
+func (r Resource) String() string {
+	if len(r.Args) == 0 {
+		return r.Target
+	}
+	return r.Target + " " + strings.Join(r.Args, " ")
+}
+
+// Matches reports whether ref names this resource
+func (r Resource) Matches(ref string) bool {
+	return ref == r.Target || ref == r.String()
+}
+
+// Command builds the command that opens the resource without starting it
+func (r Resource) Command() (*exec.Cmd, error) {
+	switch r.Type {
+	case TypeURL:
+		return openCommand(r.Target)
+	case TypeFile, TypeDir:
+		path := expandHome(r.Target)
+		if _, err := os.Stat(path); err != nil {
+			return nil, err
+		}
+		return openCommand(path)
+	case TypeApp:
+		path, err := exec.LookPath(r.Target)
+		if err != nil {
+			return nil, fmt.Errorf("could not find app: %s", r.Target)
+		}
+		return exec.Command(path, r.Args...), nil
+	case TypeShell:
+		return r.shellCommand(), nil
+	case TypeTerminal:
+		return terminalCommand(expandHome(r.Target))
+	}
+	return nil, fmt.Errorf("unknown resource type '%s'", r.Type)
+}
+
+// shellCommand runs Target with the platform's shell
+func (r Resource) shellCommand() *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", r.Target)
+	}
+	return exec.Command("sh", "-c", r.Target)
+}
+
+// openCommand opens a URL or path with the desktop's default handler
+func openCommand(target string) (*exec.Cmd, error) {
+	switch runtime.GOOS {
+	case "linux":
+		return exec.Command("xdg-open", target), nil
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", target), nil
+	case "darwin":
+		return exec.Command("open", target), nil
+	}
+	return nil, fmt.Errorf("unsupported platform")
+}
+
+// terminalCommand opens a terminal window in dir
+func terminalCommand(dir string) (*exec.Cmd, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", "-a", "Terminal", dir), nil
+	case "windows":
+		cmd := exec.Command("cmd", "/C", "start", "cmd")
+		cmd.Dir = dir
+		return cmd, nil
+	}
+
+	terminals := []string{"x-terminal-emulator", "gnome-terminal", "konsole", "alacritty", "kitty", "xterm"}
+	if env := os.Getenv("TERMINAL"); env != "" {
+		terminals = append([]string{env}, terminals...)
+	}
+	for _, term := range terminals {
+		if path, err := exec.LookPath(term); err == nil {
+			cmd := exec.Command(path)
+			cmd.Dir = dir
+			return cmd, nil
+		}
+	}
+	return nil, fmt.Errorf("could not find a terminal emulator (set $TERMINAL)")
+}
+
+// expandHome replaces a leading ~ with the home directory
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
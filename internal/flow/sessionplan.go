@@ -0,0 +1,98 @@
+package flow
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MohakGupta2004/taskgo/internal/config"
+)
+
+// DefaultSessionLength is how long a flow runs without a session plan,
+// timer.flow of the config file
+func DefaultSessionLength() time.Duration {
+	return time.Duration(config.Current().Timer.Flow)
+}
+
+// SessionPlan splits a flow run into work and break intervals. Without a
+// work length the whole session is a single block; without a total the
+// session lasts DefaultSessionLength.
+type SessionPlan struct {
+	Work  time.Duration `json:"work,omitempty"`
+	Break time.Duration `json:"break,omitempty"`
+	Total time.Duration `json:"total,omitempty"`
+}
+
+// Interval is one timer of a flow session
+type Interval struct {
+	Break    bool
+	Duration time.Duration
+}
+
+// Validate rejects negative lengths and breaks without work intervals
+func (p SessionPlan) Validate() error {
+	if p.Work < 0 || p.Break < 0 || p.Total < 0 {
+		return fmt.Errorf("session lengths must not be negative")
+	}
+	if p.Break > 0 && p.Work == 0 {
+		return fmt.Errorf("a break length needs a work length")
+	}
+	return nil
+}
+
+// Length returns the total session length
+func (p SessionPlan) Length() time.Duration {
+	if p.Total > 0 {
+		return p.Total
+	}
+	return DefaultSessionLength()
+}
+
+// Intervals returns the alternating work and break timers of the session.
+// The last one is shortened so the session ends after Length.
+func (p SessionPlan) Intervals() []Interval {
+	total := p.Length()
+	if p.Work <= 0 {
+		return []Interval{{Duration: total}}
+	}
+
+	var intervals []Interval
+	var elapsed time.Duration
+	for elapsed < total {
+		work := min(p.Work, total-elapsed)
+		intervals = append(intervals, Interval{Duration: work})
+		elapsed += work
+
+		if p.Break <= 0 || elapsed >= total {
+			continue
+		}
+		rest := min(p.Break, total-elapsed)
+		intervals = append(intervals, Interval{Break: true, Duration: rest})
+		elapsed += rest
+	}
+	return intervals
+}
+
+// String describes the plan, e.g. "25m work / 5m break for 2h"
+func (p SessionPlan) String() string {
+	if p.Work <= 0 {
+		return fmt.Sprintf("single block of %s", shortDuration(p.Length()))
+	}
+	if p.Break <= 0 {
+		return fmt.Sprintf("%s work blocks for %s", shortDuration(p.Work), shortDuration(p.Length()))
+	}
+	return fmt.Sprintf("%s work / %s break for %s", shortDuration(p.Work), shortDuration(p.Break), shortDuration(p.Length()))
+}
+
+// shortDuration formats d like time.Duration.String without trailing zero
+// units, e.g. "1h30m" instead of "1h30m0s"
+func shortDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
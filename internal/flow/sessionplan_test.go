@@ -0,0 +1,75 @@
+package flow
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSessionPlanIntervals(t *testing.T) {
+	t.Setenv("TASKGO_HOME", t.TempDir())
+
+	work := func(d time.Duration) Interval { return Interval{Duration: d} }
+	rest := func(d time.Duration) Interval { return Interval{Break: true, Duration: d} }
+	m := time.Minute
+
+	tests := []struct {
+		name string
+		plan SessionPlan
+		want []Interval
+	}{
+		{
+			name: "single block",
+			plan: SessionPlan{Total: 90 * m},
+			want: []Interval{work(90 * m)},
+		},
+		{
+			name: "default length",
+			plan: SessionPlan{},
+			want: []Interval{work(4 * time.Hour)},
+		},
+		{
+			name: "exact fit",
+			plan: SessionPlan{Work: 25 * m, Break: 5 * m, Total: 60 * m},
+			want: []Interval{work(25 * m), rest(5 * m), work(25 * m), rest(5 * m)},
+		},
+		{
+			name: "last work interval shorter than the work length",
+			plan: SessionPlan{Work: 25 * m, Break: 5 * m, Total: 70 * m},
+			want: []Interval{work(25 * m), rest(5 * m), work(25 * m), rest(5 * m), work(10 * m)},
+		},
+		{
+			name: "last break cut short",
+			plan: SessionPlan{Work: 25 * m, Break: 10 * m, Total: 30 * m},
+			want: []Interval{work(25 * m), rest(5 * m)},
+		},
+		{
+			name: "work longer than the session",
+			plan: SessionPlan{Work: 50 * m, Break: 10 * m, Total: 20 * m},
+			want: []Interval{work(20 * m)},
+		},
+		{
+			name: "work blocks without breaks",
+			plan: SessionPlan{Work: 40 * m, Total: 100 * m},
+			want: []Interval{work(40 * m), work(40 * m), work(20 * m)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.plan.Intervals()
+			if len(got) != len(tt.want) {
+				t.Fatalf("Intervals() = %v, want %v", got, tt.want)
+			}
+			var sum time.Duration
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("interval %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+				sum += got[i].Duration
+			}
+			if sum != tt.plan.Length() {
+				t.Errorf("intervals add up to %s, want %s", sum, tt.plan.Length())
+			}
+		})
+	}
+}
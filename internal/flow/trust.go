@@ -0,0 +1,132 @@
+package flow
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/MohakGupta2004/taskgo/internal/config"
+)
+
+// Project flows come from repositories and can run any command, so they
+// only run once the user has trusted the project. Trust is recorded with a
+// hash of the project's flow files and ends when any of them changes.
+
+func trustPath() (string, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "trusted_projects.json"), nil
+}
+
+// loadTrusted returns the hash of the trusted flow files by project
+// directory
+func loadTrusted() (map[string]string, error) {
+	path, err := trustPath()
+	if err != nil {
+		return nil, err
+	}
+
+	trusted := make(map[string]string)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return trusted, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &trusted); err != nil {
+		return nil, err
+	}
+	return trusted, nil
+}
+
+func saveTrusted(trusted map[string]string) error {
+	path, err := trustPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(trusted, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// hashFiles fingerprints the names and contents of files, given in a fixed
+// order, relative to dir
+func hashFiles(dir string, files []string, contents map[string][]byte) string {
+	h := sha256.New()
+	for _, file := range files {
+		name, err := filepath.Rel(dir, file)
+		if err != nil {
+			name = file
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00", filepath.ToSlash(name), len(contents[file]))
+		h.Write(contents[file])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Trusted reports whether the project flows may run
+func (m *Manager) Trusted() bool {
+	return m.trusted
+}
+
+// ProjectFiles lists the flow files of the project, including the ones
+// that were skipped
+func (m *Manager) ProjectFiles() []string {
+	return m.projectFiles
+}
+
+// Trust records that the project's flow files, as they are now, may run
+func (m *Manager) Trust() error {
+	if m.ProjectDir == "" {
+		return fmt.Errorf("no project flows found")
+	}
+	trusted, err := loadTrusted()
+	if err != nil {
+		return err
+	}
+	trusted[m.ProjectDir] = m.projectHash
+	if err := saveTrusted(trusted); err != nil {
+		return err
+	}
+	m.trusted = true
+	return nil
+}
+
+// Distrust withdraws the trust in the project's flows
+func (m *Manager) Distrust() error {
+	if m.ProjectDir == "" {
+		return fmt.Errorf("no project flows found")
+	}
+	trusted, err := loadTrusted()
+	if err != nil {
+		return err
+	}
+	delete(trusted, m.ProjectDir)
+	if err := saveTrusted(trusted); err != nil {
+		return err
+	}
+	m.trusted = false
+	return nil
+}
+
+// CheckTrusted returns an error if the flow comes from a project that has
+// not been trusted since its flow files last changed
+func (m *Manager) CheckTrusted(name string) error {
+	if m.Source(name) == "" || m.trusted {
+		return nil
+	}
+	return fmt.Errorf("flow '%s' comes from %s, which is not trusted or has changed since it was; review the files and run 'taskgo flow trust'",
+		name, filepath.Join(m.ProjectDir, ProjectFlowsDir))
+}
@@ -0,0 +1,225 @@
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MohakGupta2004/taskgo/internal/config"
+)
+
+// DefaultWorkingHours is used until working hours are configured.
+const DefaultWorkingHours = "09:00-17:00"
+
+// Block reserves a slot of a day for a task. Duration is the estimate.
+type Block struct {
+	TaskUUID string        `json:"task_uuid"`
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration"`
+}
+
+// End returns when the block is over.
+func (b Block) End() time.Time {
+	return b.Start.Add(b.Duration)
+}
+
+// Plans holds the blocks of every planned day, keyed by DateKey.
+type Plans map[string][]Block
+
+// DateKey identifies the day a plan is for.
+func DateKey(day time.Time) string {
+	return day.Format("2006-01-02")
+}
+
+// Add inserts a block into the day's plan, keeping blocks ordered by start.
+func (p Plans) Add(b Block) {
+	key := DateKey(b.Start)
+	blocks := append(p[key], b)
+	sort.SliceStable(blocks, func(i, j int) bool { return blocks[i].Start.Before(blocks[j].Start) })
+	p[key] = blocks
+}
+
+// Path returns the location of the plans file.
+func Path() (string, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "plans.json"), nil
+}
+
+// Load reads all saved plans.
+func Load() (Plans, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Plans{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	plans := Plans{}
+	if err := json.Unmarshal(data, &plans); err != nil {
+		return nil, err
+	}
+	return plans, nil
+}
+
+// Save writes all plans.
+func Save(plans Plans) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(plans, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// WorkingHours is the daily window work is planned in, as offsets from
+// midnight.
+type WorkingHours struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// ParseWorkingHours parses a range such as "09:00-17:30".
+func ParseWorkingHours(value string) (WorkingHours, error) {
+	from, to, ok := strings.Cut(value, "-")
+	if !ok {
+		return WorkingHours{}, fmt.Errorf("invalid working hours '%s'. Use HH:MM-HH:MM", value)
+	}
+
+	start, err := ParseClock(strings.TrimSpace(from))
+	if err != nil {
+		return WorkingHours{}, err
+	}
+	end, err := ParseClock(strings.TrimSpace(to))
+	if err != nil {
+		return WorkingHours{}, err
+	}
+	if end <= start {
+		return WorkingHours{}, fmt.Errorf("working hours must end after they start")
+	}
+	return WorkingHours{Start: start, End: end}, nil
+}
+
+// ParseClock parses a time of day such as "9:30" or "14:00" into an offset
+// from midnight.
+func ParseClock(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time '%s'. Use HH:MM", value)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Window returns the working hours on the given day.
+func (h WorkingHours) Window(day time.Time) (start, end time.Time) {
+	y, m, d := day.Date()
+	midnight := time.Date(y, m, d, 0, 0, 0, 0, day.Location())
+	return midnight.Add(h.Start), midnight.Add(h.End)
+}
+
+// String formats the hours as HH:MM-HH:MM.
+func (h WorkingHours) String() string {
+	clock := func(d time.Duration) string {
+		return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return clock(h.Start) + "-" + clock(h.End)
+}
+
+// Overlap is a pair of block indexes whose time ranges intersect.
+type Overlap struct {
+	A, B int
+}
+
+// Analysis lists the problems of a day's plan.
+type Analysis struct {
+	Overlaps []Overlap
+	// Outside holds the indexes of blocks not fully inside working hours.
+	Outside   []int
+	Planned   time.Duration
+	Available time.Duration
+}
+
+// OverAllocated reports whether more time is planned than there are
+// working hours.
+func (a Analysis) OverAllocated() bool {
+	return a.Planned > a.Available
+}
+
+// Analyze checks blocks (ordered by start) against each other and the
+// working hours of day.
+func Analyze(blocks []Block, hours WorkingHours, day time.Time) Analysis {
+	start, end := hours.Window(day)
+	a := Analysis{Available: end.Sub(start)}
+
+	for i, b := range blocks {
+		a.Planned += b.Duration
+		if b.Start.Before(start) || b.End().After(end) {
+			a.Outside = append(a.Outside, i)
+		}
+		for j := i + 1; j < len(blocks); j++ {
+			if blocks[j].Start.Before(b.End()) && b.Start.Before(blocks[j].End()) {
+				a.Overlaps = append(a.Overlaps, Overlap{A: i, B: j})
+			}
+		}
+	}
+	return a
+}
+
+// NextFree returns where a new block goes when no start is given: after
+// the last block, but not before working hours start or, today, before now
+// rounded up to the next five minutes.
+func NextFree(blocks []Block, hours WorkingHours, day, now time.Time) time.Time {
+	start, _ := hours.Window(day)
+	if DateKey(day) == DateKey(now) {
+		rounded := now.Truncate(5 * time.Minute)
+		if rounded.Before(now) {
+			rounded = rounded.Add(5 * time.Minute)
+		}
+		if rounded.After(start) {
+			start = rounded
+		}
+	}
+	for _, b := range blocks {
+		if b.End().After(start) {
+			start = b.End()
+		}
+	}
+	return start
+}
+
+// Current returns the index of the block running at now, or of the next
+// block to start if wait is set. ok is false if there is none.
+func Current(blocks []Block, now time.Time, wait bool) (index int, ok bool) {
+	for i, b := range blocks {
+		if !b.Start.After(now) && b.End().After(now) {
+			return i, true
+		}
+	}
+	if wait {
+		for i, b := range blocks {
+			if b.Start.After(now) {
+				return i, true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,259 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/MohakGupta2004/taskgo/internal/task"
+)
+
+// Field identifies which part of a task a match was found in.
+type Field string
+
+const (
+	FieldTitle Field = "title"
+	FieldNotes Field = "notes"
+	FieldTags  Field = "tags"
+	FieldGroup Field = "group"
+)
+
+// fieldWeights ranks matches in the title above matches in the notes.
+var fieldWeights = map[Field]int{
+	FieldTitle: 4,
+	FieldTags:  3,
+	FieldGroup: 2,
+	FieldNotes: 1,
+}
+
+// Match is a query term found in one field of a task. Positions are rune
+// offsets into Text that should be highlighted.
+type Match struct {
+	Field     Field
+	Text      string
+	Positions []int
+}
+
+// Result is a task that matched every query term.
+type Result struct {
+	Task    task.Task
+	Score   int
+	Matches []Match
+}
+
+type document struct {
+	task   task.Task
+	fields map[Field][]rune
+	lower  map[Field][]rune
+	mask   uint64
+}
+
+// Index holds pre-processed task text. Each document keeps a bitmask of the
+// characters it contains so that documents which cannot possibly match a
+// query are skipped without running the fuzzy matcher, which keeps searches
+// fast over tens of thousands of tasks.
+type Index struct {
+	docs []document
+}
+
+// NewIndex builds an index over the given tasks.
+func NewIndex(tasks []task.Task) *Index {
+	ix := &Index{docs: make([]document, 0, len(tasks))}
+	for _, t := range tasks {
+		group := t.Group
+		if group == "" {
+			group = "General"
+		}
+
+		doc := document{
+			task:   t,
+			fields: make(map[Field][]rune, 4),
+			lower:  make(map[Field][]rune, 4),
+		}
+		for field, text := range map[Field]string{
+			FieldTitle: t.Title,
+			FieldNotes: t.Notes,
+			FieldTags:  strings.Join(t.Tags, " "),
+			FieldGroup: group,
+		} {
+			if text == "" {
+				continue
+			}
+			lower := strings.ToLower(text)
+			doc.fields[field] = []rune(text)
+			doc.lower[field] = []rune(lower)
+			doc.mask |= charMask(lower)
+		}
+		ix.docs = append(ix.docs, doc)
+	}
+	return ix
+}
+
+// Search returns the tasks matching every term of the query, best first.
+// A limit of zero returns all results.
+func (ix *Index) Search(query string, limit int) []Result {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return nil
+	}
+
+	var queryMask uint64
+	for _, term := range terms {
+		queryMask |= charMask(term)
+	}
+
+	var results []Result
+	for _, doc := range ix.docs {
+		if doc.mask&queryMask != queryMask {
+			continue
+		}
+		if r, ok := doc.match(terms); ok {
+			results = append(results, r)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Task.CreatedAt.After(results[j].Task.CreatedAt)
+	})
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// match scores each term against every field and keeps the best field per
+// term. The document matches only if all terms are found.
+func (doc *document) match(terms []string) (Result, bool) {
+	result := Result{Task: doc.task}
+	matches := make(map[Field]*Match)
+
+	for _, term := range terms {
+		q := []rune(term)
+		bestScore := 0
+		var bestField Field
+		var bestPositions []int
+
+		for field, text := range doc.lower {
+			score, positions := fuzzyMatch(q, text)
+			if score == 0 {
+				continue
+			}
+			score *= fieldWeights[field]
+			if score > bestScore {
+				bestScore, bestField, bestPositions = score, field, positions
+			}
+		}
+
+		if bestScore == 0 {
+			return Result{}, false
+		}
+
+		result.Score += bestScore
+		m, ok := matches[bestField]
+		if !ok {
+			m = &Match{Field: bestField, Text: string(doc.fields[bestField])}
+			matches[bestField] = m
+		}
+		m.Positions = append(m.Positions, bestPositions...)
+	}
+
+	for _, field := range []Field{FieldTitle, FieldTags, FieldGroup, FieldNotes} {
+		if m, ok := matches[field]; ok {
+			sort.Ints(m.Positions)
+			result.Matches = append(result.Matches, *m)
+		}
+	}
+	return result, true
+}
+
+// fuzzyMatch looks for q in text, first as a substring and otherwise as a
+// subsequence. It returns zero when there is no match. Substring matches,
+// consecutive characters and matches at word starts score higher.
+func fuzzyMatch(q, text []rune) (int, []int) {
+	if len(q) == 0 || len(q) > len(text) {
+		return 0, nil
+	}
+
+	if idx := indexRunes(text, q); idx >= 0 {
+		positions := make([]int, len(q))
+		for i := range q {
+			positions[i] = idx + i
+		}
+		score := 10 * len(q)
+		if isWordStart(text, idx) {
+			score += 10
+		}
+		if len(q) == len(text) {
+			score += 10
+		}
+		return score, positions
+	}
+
+	positions := make([]int, 0, len(q))
+	score := 0
+	qi := 0
+	last := -1
+	for ti := 0; ti < len(text) && qi < len(q); ti++ {
+		if text[ti] != q[qi] {
+			continue
+		}
+		score += 1
+		if last >= 0 && ti == last+1 {
+			score += 3
+		}
+		if isWordStart(text, ti) {
+			score += 2
+		}
+		if last >= 0 {
+			score -= min(ti-last-1, 3)
+		}
+		positions = append(positions, ti)
+		last = ti
+		qi++
+	}
+
+	if qi < len(q) {
+		return 0, nil
+	}
+	return max(score, 1), positions
+}
+
+func indexRunes(text, q []rune) int {
+	for i := 0; i+len(q) <= len(text); i++ {
+		found := true
+		for j := range q {
+			if text[i+j] != q[j] {
+				found = false
+				break
+			}
+		}
+		if found {
+			return i
+		}
+	}
+	return -1
+}
+
+func isWordStart(text []rune, i int) bool {
+	return i == 0 || !unicode.IsLetter(text[i-1]) && !unicode.IsDigit(text[i-1])
+}
+
+// charMask maps letters and digits onto bits of a 64 bit mask.
+func charMask(s string) uint64 {
+	var mask uint64
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z':
+			mask |= 1 << uint(r-'a')
+		case r >= '0' && r <= '9':
+			mask |= 1 << uint(26+r-'0')
+		case r > unicode.MaxASCII:
+			mask |= 1 << (36 + uint(r)%28)
+		}
+	}
+	return mask
+}
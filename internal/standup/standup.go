@@ -0,0 +1,168 @@
+package standup
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/MohakGupta2004/taskgo/internal/task"
+)
+
+// Item is a task in the standup, with the unfinished tasks blocking it.
+type Item struct {
+	Task      task.Task
+	BlockedBy []task.Task
+}
+
+// Group collects the standup items of one task group.
+type Group struct {
+	Name    string
+	Done    []Item
+	Doing   []Item
+	Blocked []Item
+}
+
+// Report is the data passed to standup templates.
+type Report struct {
+	Date   time.Time
+	Since  time.Time
+	Groups []Group
+	Done   int
+	Doing  int
+	// Blocked counts open tasks waiting on unfinished dependencies.
+	Blocked int
+}
+
+// PreviousWorkday returns the start of the last working day before now,
+// skipping weekends so Monday's standup covers Friday.
+func PreviousWorkday(now time.Time) time.Time {
+	day := task.StartOfDay(now).AddDate(0, 0, -1)
+	for day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		day = day.AddDate(0, 0, -1)
+	}
+	return day
+}
+
+// Build gathers tasks completed since since, tasks in progress and blocked
+// open tasks, grouped by task group.
+func Build(tasks []task.Task, since, now time.Time) Report {
+	deps := task.NewDependencies(tasks)
+	groups := make(map[string]*Group)
+	get := func(name string) *Group {
+		if name == "" {
+			name = "General"
+		}
+		g, ok := groups[name]
+		if !ok {
+			g = &Group{Name: name}
+			groups[name] = g
+		}
+		return g
+	}
+
+	report := Report{Date: now, Since: since}
+	for _, t := range tasks {
+		switch {
+		case t.Status == task.StatusCompleted:
+			if t.CompletedAt != nil && !t.CompletedAt.Before(since) {
+				g := get(t.Group)
+				g.Done = append(g.Done, Item{Task: t})
+				report.Done++
+			}
+		case t.ArchivedAt != nil:
+			continue
+		case len(deps.BlockedBy(t)) > 0:
+			g := get(t.Group)
+			g.Blocked = append(g.Blocked, Item{Task: t, BlockedBy: deps.BlockedBy(t)})
+			report.Blocked++
+		case t.Status == task.StatusInProgress:
+			g := get(t.Group)
+			g.Doing = append(g.Doing, Item{Task: t})
+			report.Doing++
+		}
+	}
+
+	for _, g := range groups {
+		sort.Slice(g.Done, func(i, j int) bool { return g.Done[i].Task.CompletedAt.Before(*g.Done[j].Task.CompletedAt) })
+		sort.Slice(g.Doing, func(i, j int) bool { return g.Doing[i].Task.ID < g.Doing[j].Task.ID })
+		sort.Slice(g.Blocked, func(i, j int) bool { return g.Blocked[i].Task.ID < g.Blocked[j].Task.ID })
+		report.Groups = append(report.Groups, *g)
+	}
+	sort.Slice(report.Groups, func(i, j int) bool { return report.Groups[i].Name < report.Groups[j].Name })
+	return report
+}
+
+// Built-in templates, selected with Format.
+const (
+	MarkdownTemplate = `# Standup {{ .Date.Format "Mon 02 Jan 2006" }}
+{{ range .Groups }}
+## {{ .Name }}
+{{ if .Done }}
+**Done**
+{{ range .Done }}- {{ .Task.Title }}
+{{ end }}{{ end }}{{ if .Doing }}
+**Doing**
+{{ range .Doing }}- {{ .Task.Title }}
+{{ end }}{{ end }}{{ if .Blocked }}
+**Blocked**
+{{ range .Blocked }}- {{ .Task.Title }} (waiting on {{ titles .BlockedBy }})
+{{ end }}{{ end }}{{ else }}
+Nothing to report.
+{{ end }}`
+
+	TextTemplate = `Standup {{ .Date.Format "Mon 02 Jan 2006" }}
+{{ range .Groups }}
+{{ .Name }}
+{{ if .Done }}  Done:
+{{ range .Done }}    * {{ .Task.Title }}
+{{ end }}{{ end }}{{ if .Doing }}  Doing:
+{{ range .Doing }}    * {{ .Task.Title }}
+{{ end }}{{ end }}{{ if .Blocked }}  Blocked:
+{{ range .Blocked }}    * {{ .Task.Title }} (waiting on {{ titles .BlockedBy }})
+{{ end }}{{ end }}{{ else }}
+Nothing to report.
+{{ end }}`
+)
+
+// Formats maps the --format names to their built-in templates.
+var Formats = map[string]string{
+	"markdown": MarkdownTemplate,
+	"md":       MarkdownTemplate,
+	"text":     TextTemplate,
+	"plain":    TextTemplate,
+}
+
+var funcs = template.FuncMap{
+	"titles": func(tasks []task.Task) string {
+		titles := make([]string, len(tasks))
+		for i, t := range tasks {
+			titles[i] = t.Title
+		}
+		return strings.Join(titles, ", ")
+	},
+	"join": strings.Join,
+	"date": func(layout string, t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format(layout)
+	},
+}
+
+// Parse compiles a standup template. Templates get the titles, join and
+// date helper functions in addition to the text/template builtins.
+func Parse(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// Render executes the template with the report.
+func Render(w io.Writer, tmpl *template.Template, report Report) error {
+	return tmpl.Execute(w, report)
+}
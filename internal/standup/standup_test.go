@@ -0,0 +1,61 @@
+package standup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MohakGupta2004/taskgo/internal/task"
+)
+
+func TestBuildKeepsCompletedHistory(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	since := now.AddDate(0, 0, -1)
+	at := func(d time.Duration) *time.Time {
+		v := now.Add(d)
+		return &v
+	}
+
+	tests := []struct {
+		name string
+		task task.Task
+		done bool
+	}{
+		{
+			name: "completed yesterday in General after its validity passed",
+			task: task.Task{ID: 1, UUID: "a", Status: task.StatusCompleted, CreatedAt: now.Add(-40 * time.Hour),
+				CompletedAt: at(-20 * time.Hour), ValidUntil: at(-16 * time.Hour), ArchivedAt: at(-time.Hour)},
+			done: true,
+		},
+		{
+			name: "completed and archived by hand",
+			task: task.Task{ID: 2, UUID: "b", Group: "work", Status: task.StatusCompleted, CreatedAt: now.Add(-30 * time.Hour),
+				CompletedAt: at(-3 * time.Hour), ArchivedAt: at(-2 * time.Hour)},
+			done: true,
+		},
+		{
+			name: "completed before the report period",
+			task: task.Task{ID: 3, UUID: "c", Status: task.StatusCompleted, CreatedAt: now.Add(-72 * time.Hour),
+				CompletedAt: at(-48 * time.Hour)},
+			done: false,
+		},
+		{
+			name: "archived in progress",
+			task: task.Task{ID: 4, UUID: "d", Status: task.StatusInProgress, CreatedAt: now.Add(-30 * time.Hour),
+				ArchivedAt: at(-time.Hour)},
+			done: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := Build([]task.Task{tt.task}, since, now)
+			got := report.Done == 1
+			if got != tt.done {
+				t.Fatalf("task listed as done = %v, want %v", got, tt.done)
+			}
+			if report.Doing != 0 || report.Blocked != 0 {
+				t.Errorf("got %d doing and %d blocked, want none", report.Doing, report.Blocked)
+			}
+		})
+	}
+}
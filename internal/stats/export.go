@@ -0,0 +1,119 @@
+package stats
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WriteCSV writes the flow points as CSV with one row per sample time.
+func WriteCSV(w io.Writer, flow []FlowPoint) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"time", "todo", "in_progress", "completed", "open"}); err != nil {
+		return err
+	}
+	for _, p := range flow {
+		row := []string{
+			p.Time.Format(time.RFC3339),
+			strconv.Itoa(p.Todo),
+			strconv.Itoa(p.InProgress),
+			strconv.Itoa(p.Completed),
+			strconv.Itoa(p.Open()),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// SVG layout.
+const (
+	svgWidth   = 800
+	svgHeight  = 400
+	svgPadding = 40
+)
+
+// WriteSVG renders a cumulative flow diagram (stacked areas for completed,
+// in progress and todo) with the burndown of open tasks drawn on top.
+func WriteSVG(w io.Writer, title string, flow []FlowPoint) error {
+	if len(flow) == 0 {
+		return fmt.Errorf("no data to plot")
+	}
+
+	maxTotal := 1
+	for _, p := range flow {
+		maxTotal = max(maxTotal, p.Total())
+	}
+
+	plotW := float64(svgWidth - 2*svgPadding)
+	plotH := float64(svgHeight - 2*svgPadding)
+	x := func(i int) float64 {
+		if len(flow) == 1 {
+			return svgPadding
+		}
+		return svgPadding + plotW*float64(i)/float64(len(flow)-1)
+	}
+	y := func(v int) float64 {
+		return svgHeight - svgPadding - plotH*float64(v)/float64(maxTotal)
+	}
+
+	// band builds a closed polygon between two stacked series.
+	band := func(lower, upper func(FlowPoint) int) string {
+		var pts []string
+		for i, p := range flow {
+			pts = append(pts, fmt.Sprintf("%.1f,%.1f", x(i), y(upper(p))))
+		}
+		for i := len(flow) - 1; i >= 0; i-- {
+			pts = append(pts, fmt.Sprintf("%.1f,%.1f", x(i), y(lower(flow[i]))))
+		}
+		return strings.Join(pts, " ")
+	}
+
+	zero := func(FlowPoint) int { return 0 }
+	completed := func(p FlowPoint) int { return p.Completed }
+	inProgress := func(p FlowPoint) int { return p.Completed + p.InProgress }
+	total := func(p FlowPoint) int { return p.Total() }
+
+	var burndown []string
+	for i, p := range flow {
+		burndown = append(burndown, fmt.Sprintf("%.1f,%.1f", x(i), y(p.Open())))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="12">`+"\n", svgWidth, svgHeight)
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="#ffffff"/>`+"\n")
+	fmt.Fprintf(&b, `<text x="%d" y="24" font-size="16" font-weight="bold">%s</text>`+"\n", svgPadding, html.EscapeString(title))
+	fmt.Fprintf(&b, `<polygon points="%s" fill="#28A745" fill-opacity="0.6"/>`+"\n", band(zero, completed))
+	fmt.Fprintf(&b, `<polygon points="%s" fill="#007BFF" fill-opacity="0.6"/>`+"\n", band(completed, inProgress))
+	fmt.Fprintf(&b, `<polygon points="%s" fill="#FFA500" fill-opacity="0.6"/>`+"\n", band(inProgress, total))
+	fmt.Fprintf(&b, `<polyline points="%s" fill="none" stroke="#DC3545" stroke-width="2"/>`+"\n", strings.Join(burndown, " "))
+	fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#343A40"/>`+"\n", svgPadding, svgHeight-svgPadding, svgWidth-svgPadding, svgHeight-svgPadding)
+	fmt.Fprintf(&b, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#343A40"/>`+"\n", svgPadding, svgPadding, svgPadding, svgHeight-svgPadding)
+	fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end">%d</text>`+"\n", svgPadding-6, svgPadding+4, maxTotal)
+	fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end">0</text>`+"\n", svgPadding-6, svgHeight-svgPadding+4)
+	fmt.Fprintf(&b, `<text x="%d" y="%d">%s</text>`+"\n", svgPadding, svgHeight-svgPadding+18, flow[0].Time.Format("02 Jan"))
+	fmt.Fprintf(&b, `<text x="%d" y="%d" text-anchor="end">%s</text>`+"\n", svgWidth-svgPadding, svgHeight-svgPadding+18, flow[len(flow)-1].Time.Format("02 Jan"))
+
+	legend := []struct{ color, label string }{
+		{"#DC3545", "open (burndown)"},
+		{"#FFA500", "todo"},
+		{"#007BFF", "in progress"},
+		{"#28A745", "completed"},
+	}
+	for i, l := range legend {
+		lx := svgWidth - svgPadding - 130
+		ly := svgPadding + 16*i
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="10" height="10" fill="%s"/>`+"\n", lx, ly, l.color)
+		fmt.Fprintf(&b, `<text x="%d" y="%d">%s</text>`+"\n", lx+16, ly+10, l.label)
+	}
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,126 @@
+package stats
+
+import (
+	"strings"
+	"time"
+
+	"github.com/MohakGupta2004/taskgo/internal/task"
+)
+
+// FlowPoint is the number of tasks in each status at a point in time.
+type FlowPoint struct {
+	Time       time.Time
+	Todo       int
+	InProgress int
+	Completed  int
+}
+
+// Open returns the number of tasks not yet completed.
+func (p FlowPoint) Open() int {
+	return p.Todo + p.InProgress
+}
+
+// Total returns the number of tasks that existed at the point.
+func (p FlowPoint) Total() int {
+	return p.Todo + p.InProgress + p.Completed
+}
+
+// StatusAt reconstructs the status a task had at the given time from its
+// history. Tasks without history fall back to CreatedAt and CompletedAt.
+// ok is false if the task did not exist yet.
+func StatusAt(t task.Task, at time.Time) (status task.TaskStatus, ok bool) {
+	if t.CreatedAt.After(at) {
+		return "", false
+	}
+
+	status = task.StatusTodo
+	hasStatusHistory := false
+	for _, c := range t.History {
+		if c.Field != task.FieldStatus {
+			continue
+		}
+		hasStatusHistory = true
+		if c.Time.After(at) {
+			break
+		}
+		status = task.TaskStatus(c.To)
+	}
+
+	if !hasStatusHistory && t.CompletedAt != nil && !t.CompletedAt.After(at) {
+		status = task.StatusCompleted
+	}
+	return status, true
+}
+
+// GroupAt reconstructs the group a task was in at the given time from its
+// history. Tasks without group history have always been in their current
+// group.
+func GroupAt(t task.Task, at time.Time) string {
+	group := t.Group
+	seen := false
+	for _, c := range t.History {
+		if c.Field != task.FieldGroup {
+			continue
+		}
+		if !seen {
+			// The first move starts from the group the task was created in
+			group, seen = c.From, true
+		}
+		if c.Time.After(at) {
+			break
+		}
+		group = c.To
+	}
+	return group
+}
+
+// sameGroup compares group names like the task selector does, treating
+// the empty group as General.
+func sameGroup(a, b string) bool {
+	if a == "" {
+		a = "General"
+	}
+	if b == "" {
+		b = "General"
+	}
+	return strings.EqualFold(a, b)
+}
+
+// DailyPoints returns sample times at the end of each of the last days
+// days, with the final point at now.
+func DailyPoints(days int, now time.Time) []time.Time {
+	points := make([]time.Time, days)
+	for i := 0; i < days-1; i++ {
+		points[i] = task.EndOfDay(now.AddDate(0, 0, i-days+1))
+	}
+	points[days-1] = now
+	return points
+}
+
+// CumulativeFlow counts tasks per status at each sample time. If group is
+// set, only the tasks that were in the group at a sample are counted, so
+// tasks moved into or out of it count from or until the move.
+func CumulativeFlow(tasks []task.Task, group string, points []time.Time) []FlowPoint {
+	flow := make([]FlowPoint, len(points))
+	for i, at := range points {
+		flow[i].Time = at
+		for _, t := range tasks {
+			if group != "" && !sameGroup(GroupAt(t, at), group) {
+				continue
+			}
+			status, ok := StatusAt(t, at)
+			if !ok {
+				continue
+			}
+			switch status {
+			case task.StatusTodo:
+				flow[i].Todo++
+			case task.StatusInProgress:
+				flow[i].InProgress++
+			case task.StatusCompleted:
+				flow[i].Completed++
+			}
+		}
+	}
+	return flow
+}
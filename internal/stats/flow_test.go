@@ -0,0 +1,39 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MohakGupta2004/taskgo/internal/task"
+)
+
+func TestCumulativeFlowFollowsGroupMoves(t *testing.T) {
+	day := func(n int) time.Time { return time.Date(2026, 3, n, 12, 0, 0, 0, time.UTC) }
+	tasks := []task.Task{
+		{Title: "stayed", Group: "sprint", Status: task.StatusTodo, CreatedAt: day(1)},
+		{
+			Title: "moved in", Group: "sprint", Status: task.StatusTodo, CreatedAt: day(1),
+			History: []task.Change{{Time: day(3), Field: task.FieldGroup, From: "backlog", To: "sprint"}},
+		},
+		{
+			Title: "moved out", Group: "backlog", Status: task.StatusTodo, CreatedAt: day(1),
+			History: []task.Change{{Time: day(4), Field: task.FieldGroup, From: "Sprint", To: "backlog"}},
+		},
+		{Title: "other", Group: "", Status: task.StatusTodo, CreatedAt: day(1)},
+	}
+
+	points := []time.Time{day(2), day(3), day(4)}
+	want := []int{2, 3, 2}
+	for i, p := range CumulativeFlow(tasks, "sprint", points) {
+		if p.Total() != want[i] {
+			t.Errorf("tasks in sprint on %s = %d, want %d", p.Time.Format(time.DateOnly), p.Total(), want[i])
+		}
+	}
+
+	if got := CumulativeFlow(tasks, "general", points[:1]); got[0].Total() != 1 {
+		t.Errorf("tasks in General = %d, want the one without a group", got[0].Total())
+	}
+	if got := CumulativeFlow(tasks, "", points[:1]); got[0].Total() != len(tasks) {
+		t.Errorf("tasks without a group filter = %d, want %d", got[0].Total(), len(tasks))
+	}
+}
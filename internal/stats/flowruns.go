@@ -0,0 +1,98 @@
+package stats
+
+import (
+	"sort"
+	"time"
+
+	"github.com/MohakGupta2004/taskgo/internal/flow"
+	"github.com/MohakGupta2004/taskgo/internal/task"
+)
+
+// FlowSummary aggregates the recorded runs of one flow.
+type FlowSummary struct {
+	Flow      string
+	Sessions  int
+	Completed int
+	Focused   time.Duration
+	// Length is the summed wall-clock length of the sessions.
+	Length time.Duration
+	Last   time.Time
+}
+
+// Average returns the mean session length.
+func (s FlowSummary) Average() time.Duration {
+	if s.Sessions == 0 {
+		return 0
+	}
+	return s.Length / time.Duration(s.Sessions)
+}
+
+// SummariseFlows totals runs per flow, ordered by flow name.
+func SummariseFlows(runs []flow.Run) []FlowSummary {
+	byFlow := make(map[string]*FlowSummary)
+	for _, r := range runs {
+		s := byFlow[r.Flow]
+		if s == nil {
+			s = &FlowSummary{Flow: r.Flow}
+			byFlow[r.Flow] = s
+		}
+		s.Sessions++
+		if r.Completed {
+			s.Completed++
+		}
+		s.Focused += r.Focused
+		s.Length += r.End.Sub(r.Start)
+		if r.Start.After(s.Last) {
+			s.Last = r.Start
+		}
+	}
+
+	summaries := make([]FlowSummary, 0, len(byFlow))
+	for _, s := range byFlow {
+		summaries = append(summaries, *s)
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].Flow < summaries[j].Flow })
+	return summaries
+}
+
+// Period is a day or week focus time is reported for.
+type Period struct {
+	Start time.Time
+	End   time.Time
+	Label string
+}
+
+// LastDays returns the last n days, oldest first, ending today.
+func LastDays(n int, now time.Time) []Period {
+	today := task.StartOfDay(now)
+	periods := make([]Period, n)
+	for i := range periods {
+		start := today.AddDate(0, 0, i-n+1)
+		periods[i] = Period{Start: start, End: start.AddDate(0, 0, 1), Label: start.Format("Mon 02 Jan")}
+	}
+	return periods
+}
+
+// LastWeeks returns the last n weeks, starting on Mondays, oldest first,
+// ending with the current week.
+func LastWeeks(n int, now time.Time) []Period {
+	today := task.StartOfDay(now)
+	monday := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	periods := make([]Period, n)
+	for i := range periods {
+		start := monday.AddDate(0, 0, 7*(i-n+1))
+		periods[i] = Period{Start: start, End: start.AddDate(0, 0, 7), Label: "Week of " + start.Format("02 Jan")}
+	}
+	return periods
+}
+
+// FocusedIn sums the focused time per flow of the runs that started in p.
+func FocusedIn(runs []flow.Run, p Period) map[string]time.Duration {
+	focused := make(map[string]time.Duration)
+	for _, r := range runs {
+		if !r.Start.Before(p.Start) && r.Start.Before(p.End) {
+			focused[r.Flow] += r.Focused
+		}
+	}
+	return focused
+}
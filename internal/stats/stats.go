@@ -0,0 +1,165 @@
+package stats
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/MohakGupta2004/taskgo/internal/task"
+	"github.com/MohakGupta2004/taskgo/internal/timer"
+)
+
+// DayKey identifies a calendar day in local time.
+type DayKey string
+
+// Day returns the key of t's calendar day.
+func Day(t time.Time) DayKey {
+	return DayKey(t.Local().Format("2006-01-02"))
+}
+
+// CompletionsByDay counts completed tasks per calendar day.
+func CompletionsByDay(tasks []task.Task) map[DayKey]int {
+	counts := make(map[DayKey]int)
+	for _, t := range tasks {
+		if t.Status == task.StatusCompleted && t.CompletedAt != nil {
+			counts[Day(*t.CompletedAt)]++
+		}
+	}
+	return counts
+}
+
+// Series returns the counts for the last n days, oldest first, ending today.
+func Series(counts map[DayKey]int, n int, now time.Time) []int {
+	series := make([]int, n)
+	for i := 0; i < n; i++ {
+		day := now.AddDate(0, 0, i-n+1)
+		series[i] = counts[Day(day)]
+	}
+	return series
+}
+
+// Streaks returns the current streak (consecutive days with activity up to
+// today, or up to yesterday if nothing happened yet today) and the longest
+// streak ever.
+func Streaks(counts map[DayKey]int, now time.Time) (current, longest int) {
+	if len(counts) == 0 {
+		return 0, 0
+	}
+
+	days := make([]string, 0, len(counts))
+	for d, c := range counts {
+		if c > 0 {
+			days = append(days, string(d))
+		}
+	}
+	sort.Strings(days)
+
+	run := 0
+	var prev time.Time
+	for _, d := range days {
+		day, _ := time.ParseInLocation("2006-01-02", d, time.Local)
+		if run > 0 && day.Sub(prev) <= 25*time.Hour {
+			run++
+		} else {
+			run = 1
+		}
+		longest = max(longest, run)
+		prev = day
+	}
+
+	day := now
+	if counts[Day(day)] == 0 {
+		day = day.AddDate(0, 0, -1)
+	}
+	for counts[Day(day)] > 0 {
+		current++
+		day = day.AddDate(0, 0, -1)
+	}
+	return current, longest
+}
+
+// LeadTime summarises created→completed durations for a group.
+type LeadTime struct {
+	Group string
+	Count int
+	P50   time.Duration
+	P90   time.Duration
+	Max   time.Duration
+}
+
+// LeadTimes computes lead time percentiles per group, sorted by group name.
+func LeadTimes(tasks []task.Task) []LeadTime {
+	byGroup := make(map[string][]time.Duration)
+	for _, t := range tasks {
+		if t.Status != task.StatusCompleted || t.CompletedAt == nil {
+			continue
+		}
+		group := t.Group
+		if group == "" {
+			group = "General"
+		}
+		byGroup[group] = append(byGroup[group], t.CompletedAt.Sub(t.CreatedAt))
+	}
+
+	var result []LeadTime
+	for group, durations := range byGroup {
+		sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+		result = append(result, LeadTime{
+			Group: group,
+			Count: len(durations),
+			P50:   Percentile(durations, 50),
+			P90:   Percentile(durations, 90),
+			Max:   durations[len(durations)-1],
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Group < result[j].Group })
+	return result
+}
+
+// Percentile returns the p-th percentile of sorted durations using the
+// nearest-rank method.
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	rank = min(max(rank, 0), len(sorted)-1)
+	return sorted[rank]
+}
+
+// Pomodoros summarises completed focus timers.
+type Pomodoros struct {
+	Today    int
+	Week     int
+	Total    int
+	Focused  time.Duration
+	PerDay   map[DayKey]int
+	Quitters int
+}
+
+// SummarisePomodoros counts completed focus runs from the timer log.
+// Runs that were quit early are counted separately.
+func SummarisePomodoros(records []timer.Record, now time.Time) Pomodoros {
+	p := Pomodoros{PerDay: make(map[DayKey]int)}
+	weekStart := task.StartOfDay(now).AddDate(0, 0, -6)
+
+	for _, r := range records {
+		if !r.IsFocus() {
+			continue
+		}
+		if !r.Completed {
+			p.Quitters++
+			continue
+		}
+		p.Total++
+		p.Focused += r.Planned
+		p.PerDay[Day(r.Start)]++
+		if Day(r.Start) == Day(now) {
+			p.Today++
+		}
+		if !r.Start.Before(weekStart) {
+			p.Week++
+		}
+	}
+	return p
+}
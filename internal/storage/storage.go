@@ -52,3 +52,22 @@ func (s *JSONStorage) Save(tasks []task.Task) error {
 
 	return os.WriteFile(s.FilePath, data, 0644)
 }
+
+// unavailable is a Storage that cannot be used, e.g. because the config
+// file does not say where tasks are kept.
+type unavailable struct {
+	err error
+}
+
+// Unavailable returns a Storage that fails every load and save with err.
+func Unavailable(err error) Storage {
+	return unavailable{err: err}
+}
+
+func (s unavailable) Load() ([]task.Task, error) {
+	return nil, s.err
+}
+
+func (s unavailable) Save(tasks []task.Task) error {
+	return s.err
+}
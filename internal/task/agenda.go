@@ -0,0 +1,42 @@
+package task
+
+import (
+	"sort"
+	"time"
+)
+
+// EventKind tells which deadline of a task an Event is about.
+type EventKind string
+
+const (
+	EventDue     EventKind = "due"
+	EventExpires EventKind = "expires"
+)
+
+// Event is a dated deadline of an open task.
+type Event struct {
+	Task Task
+	Time time.Time
+	Kind EventKind
+}
+
+// Events returns the due dates and validity expirations of open tasks
+// before until, in chronological order. Deadlines in the past are included
+// so callers can show overdue tasks.
+func Events(tasks []Task, until time.Time) []Event {
+	var events []Event
+	for _, t := range tasks {
+		if t.Status == StatusCompleted {
+			continue
+		}
+		if t.Due != nil && t.Due.Before(until) {
+			events = append(events, Event{Task: t, Time: *t.Due, Kind: EventDue})
+		}
+		if t.ValidUntil != nil && t.ValidUntil.Before(until) {
+			events = append(events, Event{Task: t, Time: *t.ValidUntil, Kind: EventExpires})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Time.Before(events[j].Time) })
+	return events
+}
@@ -0,0 +1,197 @@
+package task
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// documentHeader is the YAML front matter of an editable task document.
+// The notes follow the closing delimiter as free-form Markdown.
+type documentHeader struct {
+	Title      string   `yaml:"title"`
+	Group      string   `yaml:"group"`
+	Status     string   `yaml:"status"`
+	ValidUntil string   `yaml:"valid_until"`
+	Due        string   `yaml:"due"`
+	Tags       []string `yaml:"tags"`
+}
+
+const frontMatterDelimiter = "---"
+
+// FieldError describes a problem with a single field of a task document.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// DocumentError collects every field error found while applying a document.
+type DocumentError struct {
+	Errors []FieldError
+}
+
+func (e *DocumentError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = fe.Field + ": " + fe.Message
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// MarshalDocument renders a task as YAML front matter followed by its notes.
+func MarshalDocument(t Task) ([]byte, error) {
+	header := documentHeader{
+		Title:  t.Title,
+		Group:  t.Group,
+		Status: string(t.Status),
+		Tags:   t.Tags,
+	}
+	if header.Group == "" {
+		header.Group = "General"
+	}
+	if t.ValidUntil != nil {
+		header.ValidUntil = t.ValidUntil.Format(time.RFC3339)
+	}
+	if t.Due != nil {
+		header.Due = t.Due.Format(time.RFC3339)
+	}
+	if header.Tags == nil {
+		header.Tags = []string{}
+	}
+
+	data, err := yaml.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(frontMatterDelimiter + "\n")
+	buf.WriteString("# valid_until accepts a timestamp (RFC 3339), a duration from now (e.g. 2h) or 'none'.\n")
+	buf.WriteString("# due also accepts today, tomorrow, a weekday, 3d or 2006-01-02.\n")
+	buf.Write(data)
+	buf.WriteString(frontMatterDelimiter + "\n")
+	if t.Notes != "" {
+		buf.WriteString(t.Notes)
+		if !strings.HasSuffix(t.Notes, "\n") {
+			buf.WriteString("\n")
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// ApplyDocument validates an edited document and copies its fields onto t.
+// t is left untouched when the document is invalid; field problems are
+// reported as a *DocumentError.
+func ApplyDocument(t *Task, data []byte) error {
+	front, notes, err := splitFrontMatter(string(data))
+	if err != nil {
+		return &DocumentError{Errors: []FieldError{{Field: "document", Message: err.Error()}}}
+	}
+
+	var header documentHeader
+	if err := yaml.Unmarshal([]byte(front), &header); err != nil {
+		return &DocumentError{Errors: []FieldError{{Field: "front matter", Message: err.Error()}}}
+	}
+
+	var errs []FieldError
+	updated := *t
+
+	updated.Title = strings.TrimSpace(header.Title)
+	if updated.Title == "" {
+		errs = append(errs, FieldError{Field: "title", Message: "must not be empty"})
+	}
+
+	updated.Group = strings.TrimSpace(header.Group)
+	if updated.Group == "" {
+		updated.Group = "General"
+	}
+
+	status, err := ParseStatus(strings.TrimSpace(header.Status))
+	if err != nil {
+		errs = append(errs, FieldError{Field: "status", Message: "must be one of todo, in-progress, completed"})
+	} else if status != t.Status {
+		updated.Status = status
+		if status == StatusCompleted {
+			now := time.Now()
+			updated.CompletedAt = &now
+		} else {
+			updated.CompletedAt = nil
+		}
+	}
+
+	validUntil, err := parseValidUntil(strings.TrimSpace(header.ValidUntil))
+	if err != nil {
+		errs = append(errs, FieldError{Field: "valid_until", Message: err.Error()})
+	} else {
+		updated.ValidUntil = validUntil
+	}
+
+	due, err := ParseDue(header.Due, time.Now())
+	if err != nil {
+		errs = append(errs, FieldError{Field: "due", Message: err.Error()})
+	} else {
+		updated.Due = due
+	}
+
+	updated.Tags = nil
+	for _, tag := range header.Tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		if strings.ContainsAny(tag, " \t,") {
+			errs = append(errs, FieldError{Field: "tags", Message: fmt.Sprintf("tag '%s' must not contain spaces or commas", tag)})
+			continue
+		}
+		updated.Tags = append(updated.Tags, tag)
+	}
+
+	updated.Notes = strings.TrimSpace(notes)
+
+	if len(errs) > 0 {
+		return &DocumentError{Errors: errs}
+	}
+
+	*t = updated
+	return nil
+}
+
+// splitFrontMatter separates the YAML header from the notes body.
+func splitFrontMatter(doc string) (string, string, error) {
+	doc = strings.TrimLeft(doc, "\r\n")
+	lines := strings.SplitAfter(doc, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != frontMatterDelimiter {
+		return "", "", fmt.Errorf("must start with a '%s' line", frontMatterDelimiter)
+	}
+
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == frontMatterDelimiter {
+			front := strings.Join(lines[1:i], "")
+			body := strings.Join(lines[i+1:], "")
+			return front, body, nil
+		}
+	}
+	return "", "", fmt.Errorf("missing closing '%s' line after the front matter", frontMatterDelimiter)
+}
+
+// parseValidUntil accepts an RFC 3339 timestamp, a duration relative to now,
+// or an empty value / "none" to clear the validity.
+func parseValidUntil(value string) (*time.Time, error) {
+	if value == "" || value == "none" {
+		return nil, nil
+	}
+
+	if ts, err := time.Parse(time.RFC3339, value); err == nil {
+		return &ts, nil
+	}
+
+	if d, err := time.ParseDuration(value); err == nil {
+		ts := time.Now().Add(d)
+		return &ts, nil
+	}
+
+	return nil, fmt.Errorf("'%s' is neither a timestamp (e.g. 2006-01-02T15:04:05Z) nor a duration (e.g. 2h)", value)
+}
@@ -0,0 +1,94 @@
+package task
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dueLayouts are the absolute formats accepted for due dates.
+var dueLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// ParseDue parses a due date relative to now. It accepts "today",
+// "tomorrow", a weekday name, an offset such as "3d" or "2h", or a date in
+// one of dueLayouts. Dates without a time are due at the end of that day.
+// An empty value or "none" clears the due date.
+func ParseDue(value string, now time.Time) (*time.Time, error) {
+	raw := strings.TrimSpace(value)
+	value = strings.ToLower(raw)
+	if value == "" || value == "none" {
+		return nil, nil
+	}
+
+	switch value {
+	case "today":
+		t := EndOfDay(now)
+		return &t, nil
+	case "tomorrow":
+		t := EndOfDay(now.AddDate(0, 0, 1))
+		return &t, nil
+	}
+
+	for offset := 1; offset <= 7; offset++ {
+		day := now.AddDate(0, 0, offset)
+		name := strings.ToLower(day.Weekday().String())
+		if value == name || value == name[:3] {
+			t := EndOfDay(day)
+			return &t, nil
+		}
+	}
+
+	if d, err := ParseAge(value); err == nil {
+		t := now.Add(d)
+		return &t, nil
+	}
+
+	for _, layout := range dueLayouts {
+		if t, err := time.ParseInLocation(layout, raw, now.Location()); err == nil {
+			if layout == "2006-01-02" {
+				t = EndOfDay(t)
+			}
+			return &t, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid due date '%s'. Use e.g. today, tomorrow, friday, 3d, 2006-01-02 or '2006-01-02 15:04'", raw)
+}
+
+// ParseAge parses a duration that may also use days (d) and weeks (w),
+// e.g. "7d", "2w" or "36h".
+func ParseAge(value string) (time.Duration, error) {
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(value, suffix); ok {
+			count, err := strconv.Atoi(n)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration '%s'", value)
+			}
+			return time.Duration(count) * unit, nil
+		}
+	}
+	return time.ParseDuration(value)
+}
+
+// StartOfDay returns midnight at the start of t's day.
+func StartOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// EndOfDay returns the last minute of t's day.
+func EndOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 23, 59, 0, 0, t.Location())
+}
+
+// IsOverdue reports whether an open task is past its due date.
+func IsOverdue(t Task, now time.Time) bool {
+	return t.Due != nil && t.Status != StatusCompleted && t.Due.Before(now)
+}
@@ -0,0 +1,107 @@
+package task
+
+import (
+	"strings"
+	"time"
+)
+
+// Change records a single field modification of a task.
+type Change struct {
+	Time  time.Time `json:"time"`
+	Field string    `json:"field"`
+	From  string    `json:"from,omitempty"`
+	To    string    `json:"to,omitempty"`
+}
+
+// Fields tracked in a task's history.
+const (
+	FieldTitle      = "title"
+	FieldGroup      = "group"
+	FieldStatus     = "status"
+	FieldValidUntil = "valid_until"
+	FieldDue        = "due"
+	FieldNotes      = "notes"
+	FieldTags       = "tags"
+	FieldDependsOn  = "depends_on"
+	FieldArchivedAt = "archived_at"
+)
+
+// recordChanges appends a Change to after.History for every tracked field
+// that differs between before and after.
+func recordChanges(before, after *Task, now time.Time) {
+	add := func(field, from, to string) {
+		if from != to {
+			after.History = append(after.History, Change{Time: now, Field: field, From: from, To: to})
+		}
+	}
+
+	add(FieldTitle, before.Title, after.Title)
+	add(FieldGroup, before.Group, after.Group)
+	add(FieldStatus, string(before.Status), string(after.Status))
+	add(FieldValidUntil, formatHistoryTime(before.ValidUntil), formatHistoryTime(after.ValidUntil))
+	add(FieldDue, formatHistoryTime(before.Due), formatHistoryTime(after.Due))
+	add(FieldTags, strings.Join(before.Tags, ","), strings.Join(after.Tags, ","))
+	add(FieldDependsOn, strings.Join(before.DependsOn, ","), strings.Join(after.DependsOn, ","))
+	add(FieldArchivedAt, formatHistoryTime(before.ArchivedAt), formatHistoryTime(after.ArchivedAt))
+
+	// Notes can be long, so only the fact that they changed is recorded.
+	if before.Notes != after.Notes {
+		after.History = append(after.History, Change{Time: now, Field: FieldNotes})
+	}
+}
+
+func formatHistoryTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// LastChange returns when the task was last modified, falling back to its
+// creation time for tasks without history.
+func LastChange(t Task) time.Time {
+	last := t.CreatedAt
+	for _, c := range t.History {
+		if c.Time.After(last) {
+			last = c.Time
+		}
+	}
+	return last
+}
+
+// StatusSince returns when the task entered its current status, falling
+// back to its creation time for tasks without status history.
+func StatusSince(t Task) time.Time {
+	since := t.CreatedAt
+	for _, c := range t.History {
+		if c.Field == FieldStatus && c.To == string(t.Status) {
+			since = c.Time
+		}
+	}
+	return since
+}
+
+// TimeSpent sums the time a task spent in progress according to its status
+// history, counting up to now if it is still in progress.
+func TimeSpent(t Task, now time.Time) time.Duration {
+	var spent time.Duration
+	var startedAt *time.Time
+
+	for _, c := range t.History {
+		if c.Field != FieldStatus {
+			continue
+		}
+		if c.To == string(StatusInProgress) && startedAt == nil {
+			at := c.Time
+			startedAt = &at
+		} else if c.From == string(StatusInProgress) && startedAt != nil {
+			spent += c.Time.Sub(*startedAt)
+			startedAt = nil
+		}
+	}
+
+	if startedAt != nil && t.Status == StatusInProgress {
+		spent += now.Sub(*startedAt)
+	}
+	return spent
+}
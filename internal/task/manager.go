@@ -2,6 +2,10 @@ package task
 
 import (
 	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/MohakGupta2004/taskgo/internal/config"
@@ -22,15 +26,45 @@ func NewManager(storage Storage) *Manager {
 	return &Manager{storage: storage}
 }
 
-func (m *Manager) Add(title string, group string, validity string) error {
+// load reads all tasks and assigns a UUID to tasks created before UUIDs
+// were introduced.
+func (m *Manager) load() ([]Task, error) {
 	tasks, err := m.storage.Load()
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	missing := false
+	for i := range tasks {
+		if tasks[i].UUID == "" {
+			tasks[i].UUID = newUUID()
+			missing = true
+		}
 	}
 
+	if missing {
+		if err := m.storage.Save(tasks); err != nil {
+			return nil, err
+		}
+	}
+	return tasks, nil
+}
+
+// Add creates a task and returns it. due may be nil. dependsOn holds the
+// UUIDs of tasks the new task depends on; nothing is saved if one of them
+// does not exist.
+func (m *Manager) Add(title string, group string, validity string, due *time.Time, dependsOn []string) (Task, error) {
+	tasks, err := m.load()
+	if err != nil {
+		return Task{}, err
+	}
+
+	// Short IDs are only for display; the UUID is the permanent identity.
 	id := 1
-	if len(tasks) > 0 {
-		id = tasks[len(tasks)-1].ID + 1
+	for _, t := range tasks {
+		if t.ID >= id {
+			id = t.ID + 1
+		}
 	}
 
 	if group == "" {
@@ -47,17 +81,11 @@ func (m *Manager) Add(title string, group string, validity string) error {
 			duration = d
 		}
 	} else {
-		// Load context for group validity
-		ctx, err := config.LoadContext()
-		if err == nil {
-			if v, ok := ctx.GroupValidity[group]; ok {
-				d, err := time.ParseDuration(v)
-				if err == nil {
-					duration = d
-				}
-			} else if group == "General" {
-				// Default for General if not in config
-				duration = 24 * time.Hour
+		// Use the configured validity of the group
+		if v, ok := config.Current().Defaults.GroupValidity[group]; ok {
+			d, err := time.ParseDuration(v)
+			if err == nil {
+				duration = d
 			}
 		}
 	}
@@ -67,21 +95,33 @@ func (m *Manager) Add(title string, group string, validity string) error {
 		validUntil = &t
 	}
 
+	now := time.Now()
 	newTask := Task{
 		ID:         id,
+		UUID:       newUUID(),
 		Title:      title,
 		Group:      group,
 		Status:     StatusTodo,
-		CreatedAt:  time.Now(),
+		CreatedAt:  now,
 		ValidUntil: validUntil,
+		Due:        due,
+		DependsOn:  dependsOn,
+		History:    []Change{{Time: now, Field: FieldStatus, To: string(StatusTodo)}},
+	}
+	if err := dependencyCheck(tasks, dependsOn)(&newTask); err != nil {
+		return Task{}, err
 	}
 
 	tasks = append(tasks, newTask)
-	return m.storage.Save(tasks)
+	return newTask, m.storage.Save(tasks)
 }
 
+// CleanupExpired deletes open tasks whose validity has passed. Completed
+// tasks are archived instead, so stats, burndowns and standups keep their
+// history. Archived tasks are kept: archiving is how tasks are hidden
+// without losing them.
 func (m *Manager) CleanupExpired() error {
-	tasks, err := m.storage.Load()
+	tasks, err := m.load()
 	if err != nil {
 		return err
 	}
@@ -91,9 +131,14 @@ func (m *Manager) CleanupExpired() error {
 	changed := false
 
 	for _, t := range tasks {
-		if t.ValidUntil != nil && t.ValidUntil.Before(now) {
+		if t.ArchivedAt == nil && t.ValidUntil != nil && t.ValidUntil.Before(now) {
 			changed = true
-			continue
+			if t.Status != StatusCompleted {
+				continue
+			}
+			before := t
+			t.ArchivedAt = &now
+			recordChanges(&before, &t, now)
 		}
 		newTasks = append(newTasks, t)
 	}
@@ -108,76 +153,235 @@ func (m *Manager) List() ([]Task, error) {
 	if err := m.CleanupExpired(); err != nil {
 		return nil, err
 	}
-	return m.storage.Load()
+	return m.load()
 }
 
-func (m *Manager) Update(id int, status TaskStatus) error {
-	tasks, err := m.storage.Load()
+// Get returns the task with the given ID.
+func (m *Manager) Get(id int) (Task, error) {
+	tasks, err := m.List()
 	if err != nil {
-		return err
+		return Task{}, err
 	}
 
-	found := false
-	for i, t := range tasks {
+	for _, t := range tasks {
 		if t.ID == id {
-			tasks[i].Status = status
-			if status == StatusCompleted {
-				now := time.Now()
-				tasks[i].CompletedAt = &now
-			} else {
-				tasks[i].CompletedAt = nil
-			}
-			found = true
-			break
+			return t, nil
 		}
 	}
+	return Task{}, errors.New("task not found")
+}
 
-	if !found {
-		return errors.New("task not found")
+// Resolve finds a task by short ID or by a unique UUID prefix.
+func (m *Manager) Resolve(ref string) (Task, error) {
+	if id, err := strconv.Atoi(ref); err == nil {
+		return m.Get(id)
 	}
 
-	return m.storage.Save(tasks)
+	if !isUUIDPrefix(ref) {
+		return Task{}, fmt.Errorf("invalid task reference '%s'", ref)
+	}
+
+	tasks, err := m.List()
+	if err != nil {
+		return Task{}, err
+	}
+
+	matches := matchUUIDPrefix(tasks, ref)
+	switch len(matches) {
+	case 0:
+		return Task{}, errors.New("task not found")
+	case 1:
+		return matches[0], nil
+	default:
+		return Task{}, fmt.Errorf("UUID prefix '%s' is ambiguous (%d tasks)", ref, len(matches))
+	}
 }
 
-func (m *Manager) UpdateTitle(id int, title string) error {
-	tasks, err := m.storage.Load()
+func matchUUIDPrefix(tasks []Task, prefix string) []Task {
+	prefix = strings.ToLower(prefix)
+	var matches []Task
+	for _, t := range tasks {
+		if strings.HasPrefix(t.UUID, prefix) {
+			matches = append(matches, t)
+		}
+	}
+	return matches
+}
+
+// Edit applies a task document made from before to the stored task. Only
+// the fields the document changed are copied, so changes saved while it
+// was being edited, such as annotations or a new status, are kept. Field
+// problems are reported as a *DocumentError.
+func (m *Manager) Edit(before Task, document []byte) error {
+	original, err := MarshalDocument(before)
 	if err != nil {
 		return err
 	}
 
-	found := false
-	for i, t := range tasks {
-		if t.ID == id {
-			tasks[i].Title = title
-			found = true
-			break
+	// Fields change on their way through a document, e.g. times lose their
+	// fraction, so the edit is compared with the unedited document.
+	base := before
+	if err := ApplyDocument(&base, original); err != nil {
+		return err
+	}
+	edited := before
+	if err := ApplyDocument(&edited, document); err != nil {
+		return err
+	}
+
+	return m.apply([]int{before.ID}, func(t *Task) error {
+		copyEdited(t, &base, &edited)
+		return nil
+	})
+}
+
+// copyEdited copies the document fields that differ between base and
+// edited onto t.
+func copyEdited(t, base, edited *Task) {
+	if edited.Title != base.Title {
+		t.Title = edited.Title
+	}
+	if edited.Group != base.Group {
+		t.Group = edited.Group
+	}
+	if edited.Status != base.Status && edited.Status != t.Status {
+		t.Status = edited.Status
+		t.CompletedAt = edited.CompletedAt
+	}
+	if formatHistoryTime(edited.ValidUntil) != formatHistoryTime(base.ValidUntil) {
+		t.ValidUntil = edited.ValidUntil
+	}
+	if formatHistoryTime(edited.Due) != formatHistoryTime(base.Due) {
+		t.Due = edited.Due
+	}
+	if !slices.Equal(edited.Tags, base.Tags) {
+		t.Tags = edited.Tags
+	}
+	if edited.Notes != base.Notes {
+		t.Notes = edited.Notes
+	}
+}
+
+// Select returns the tasks matched by the selector, in storage order.
+func (m *Manager) Select(sel *Selector) ([]Task, error) {
+	tasks, err := m.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, prefix := range sel.UUIDPrefixes {
+		matches := matchUUIDPrefix(tasks, prefix)
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no task matches UUID prefix '%s'", prefix)
+		}
+		if len(matches) > 1 {
+			return nil, fmt.Errorf("UUID prefix '%s' is ambiguous (%d tasks)", prefix, len(matches))
 		}
 	}
 
-	if !found {
+	var selected []Task
+	for _, t := range tasks {
+		if sel.Match(t) {
+			selected = append(selected, t)
+		}
+	}
+	return selected, nil
+}
+
+// apply runs fn on every task in ids within a single load/save cycle and
+// records the resulting field changes in each task's history.
+// Nothing is saved if a task is missing or fn fails.
+func (m *Manager) apply(ids []int, fn func(t *Task) error) error {
+	tasks, err := m.load()
+	if err != nil {
+		return err
+	}
+
+	pending := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		pending[id] = true
+	}
+
+	now := time.Now()
+	for i := range tasks {
+		if !pending[tasks[i].ID] {
+			continue
+		}
+		before := tasks[i]
+		if err := fn(&tasks[i]); err != nil {
+			return err
+		}
+		recordChanges(&before, &tasks[i], now)
+		delete(pending, tasks[i].ID)
+	}
+
+	if len(pending) > 0 {
 		return errors.New("task not found")
 	}
 
 	return m.storage.Save(tasks)
 }
 
+func (m *Manager) Update(id int, status TaskStatus) error {
+	return m.UpdateStatusMany([]int{id}, status)
+}
+
+// UpdateStatusMany sets the status of every task in ids and saves once.
+func (m *Manager) UpdateStatusMany(ids []int, status TaskStatus) error {
+	return m.apply(ids, func(t *Task) error {
+		t.Status = status
+		if status == StatusCompleted {
+			now := time.Now()
+			t.CompletedAt = &now
+		} else {
+			t.CompletedAt = nil
+		}
+		return nil
+	})
+}
+
+func (m *Manager) UpdateTitle(id int, title string) error {
+	return m.apply([]int{id}, func(t *Task) error {
+		t.Title = title
+		return nil
+	})
+}
+
+// Annotate appends a timestamped annotation to a task. Annotations are
+// never edited or removed.
+func (m *Manager) Annotate(id int, text string) error {
+	return m.apply([]int{id}, func(t *Task) error {
+		t.Annotations = append(t.Annotations, Annotation{Time: time.Now(), Text: text})
+		return nil
+	})
+}
+
 func (m *Manager) Remove(id int) error {
-	tasks, err := m.storage.Load()
+	return m.RemoveMany([]int{id})
+}
+
+// RemoveMany deletes every task in ids and saves once.
+func (m *Manager) RemoveMany(ids []int) error {
+	tasks, err := m.load()
 	if err != nil {
 		return err
 	}
 
+	remove := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		remove[id] = true
+	}
+
 	newTasks := []Task{}
-	found := false
 	for _, t := range tasks {
-		if t.ID == id {
-			found = true
+		if remove[t.ID] {
+			delete(remove, t.ID)
 			continue
 		}
 		newTasks = append(newTasks, t)
 	}
 
-	if !found {
+	if len(remove) > 0 {
 		return errors.New("task not found")
 	}
 
@@ -185,44 +389,167 @@ func (m *Manager) Remove(id int) error {
 }
 
 func (m *Manager) UpdateValidity(id int, validity string) error {
-	tasks, err := m.storage.Load()
-	if err != nil {
-		return err
+	return m.UpdateValidityMany([]int{id}, validity)
+}
+
+// UpdateValidityMany resets the validity of every task in ids and saves once.
+func (m *Manager) UpdateValidityMany(ids []int, validity string) error {
+	return m.EditMany(ids, TaskEdit{SetValidity: true, Validity: validity})
+}
+
+// UpdateDueMany sets or clears the due date of every task in ids and saves once.
+func (m *Manager) UpdateDueMany(ids []int, due *time.Time) error {
+	return m.EditMany(ids, TaskEdit{SetDue: true, Due: due})
+}
+
+// UpdateGroupMany moves every task in ids to group and saves once.
+func (m *Manager) UpdateGroupMany(ids []int, group string) error {
+	return m.apply(ids, func(t *Task) error {
+		t.Group = group
+		return nil
+	})
+}
+
+// ArchiveMany hides every task in ids from lists, reports and the default
+// selection without deleting it, and saves once.
+func (m *Manager) ArchiveMany(ids []int) error {
+	now := time.Now()
+	return m.apply(ids, func(t *Task) error {
+		if t.ArchivedAt == nil {
+			t.ArchivedAt = &now
+		}
+		return nil
+	})
+}
+
+// UnarchiveMany restores archived tasks in ids and saves once. A validity
+// that passed while the task was archived is cleared so the restored task
+// is not deleted right away.
+func (m *Manager) UnarchiveMany(ids []int) error {
+	now := time.Now()
+	return m.apply(ids, func(t *Task) error {
+		t.ArchivedAt = nil
+		if t.ValidUntil != nil && t.ValidUntil.Before(now) {
+			t.ValidUntil = nil
+		}
+		return nil
+	})
+}
+
+// UpdateDependenciesMany makes every task in ids depend on the tasks with
+// the given UUIDs, replacing previous dependencies. Self references and
+// cycles are rejected.
+func (m *Manager) UpdateDependenciesMany(ids []int, uuids []string) error {
+	return m.EditMany(ids, TaskEdit{SetDependencies: true, DependsOn: uuids})
+}
+
+// TaskEdit holds the field changes of EditMany. Only fields whose Set flag
+// is true are changed.
+type TaskEdit struct {
+	SetValidity bool
+	// Validity is a duration from now, or "none" to clear it.
+	Validity        string
+	SetDue          bool
+	Due             *time.Time
+	SetDependencies bool
+	DependsOn       []string
+}
+
+// EditMany applies all changes of e to every task in ids and saves once.
+// Nothing is saved if any change is invalid for any task.
+func (m *Manager) EditMany(ids []int, e TaskEdit) error {
+	var validUntil *time.Time
+	if e.SetValidity && e.Validity != "" && e.Validity != "none" {
+		d, err := time.ParseDuration(e.Validity)
+		if err != nil {
+			return errors.New("invalid duration format")
+		}
+		t := time.Now().Add(d)
+		validUntil = &t
 	}
 
-	found := false
-	for i, t := range tasks {
-		if t.ID == id {
-			if validity == "" || validity == "none" {
-				// Remove validity
-				tasks[i].ValidUntil = nil
-			} else {
-				// Parse and set new validity
-				d, err := time.ParseDuration(validity)
-				if err != nil {
-					return errors.New("invalid duration format")
-				}
-				newValidUntil := time.Now().Add(d)
-				tasks[i].ValidUntil = &newValidUntil
+	var checkDependencies func(t *Task) error
+	if e.SetDependencies {
+		tasks, err := m.load()
+		if err != nil {
+			return err
+		}
+		checkDependencies = dependencyCheck(tasks, e.DependsOn)
+	}
+
+	return m.apply(ids, func(t *Task) error {
+		if e.SetDependencies {
+			if err := checkDependencies(t); err != nil {
+				return err
 			}
-			found = true
-			break
+			t.DependsOn = e.DependsOn
+		}
+		if e.SetValidity {
+			t.ValidUntil = validUntil
+		}
+		if e.SetDue {
+			t.Due = e.Due
 		}
+		return nil
+	})
+}
+
+// dependencyCheck returns a function rejecting uuids as dependencies of a
+// task if one of them does not exist or would create a cycle.
+func dependencyCheck(tasks []Task, uuids []string) func(t *Task) error {
+	byUUID := make(map[string]Task, len(tasks))
+	for _, t := range tasks {
+		byUUID[t.UUID] = t
 	}
 
-	if !found {
-		return errors.New("task not found")
+	// dependsOn reports whether from (transitively) depends on target.
+	var dependsOn func(from, target string, seen map[string]bool) bool
+	dependsOn = func(from, target string, seen map[string]bool) bool {
+		if from == target {
+			return true
+		}
+		if seen[from] {
+			return false
+		}
+		seen[from] = true
+		for _, dep := range byUUID[from].DependsOn {
+			if dependsOn(dep, target, seen) {
+				return true
+			}
+		}
+		return false
 	}
 
-	return m.storage.Save(tasks)
+	return func(t *Task) error {
+		for _, dep := range uuids {
+			if _, ok := byUUID[dep]; !ok {
+				return fmt.Errorf("dependency %s not found", dep)
+			}
+			if dependsOn(dep, t.UUID, map[string]bool{}) {
+				return fmt.Errorf("task %d cannot depend on task %d: that would create a cycle", t.ID, byUUID[dep].ID)
+			}
+		}
+		return nil
+	}
 }
 
 func (m *Manager) UpdateGroupValidity(group string, validity string) error {
-	tasks, err := m.storage.Load()
+	tasks, err := m.load()
 	if err != nil {
 		return err
 	}
 
+	var validUntil *time.Time
+	if validity != "" && validity != "none" {
+		d, err := time.ParseDuration(validity)
+		if err != nil {
+			return errors.New("invalid duration format")
+		}
+		t := time.Now().Add(d)
+		validUntil = &t
+	}
+
+	now := time.Now()
 	updated := false
 	for i, t := range tasks {
 		taskGroup := t.Group
@@ -231,18 +558,8 @@ func (m *Manager) UpdateGroupValidity(group string, validity string) error {
 		}
 
 		if taskGroup == group {
-			if validity == "" || validity == "none" {
-				// Remove validity
-				tasks[i].ValidUntil = nil
-			} else {
-				// Parse and set new validity
-				d, err := time.ParseDuration(validity)
-				if err != nil {
-					return errors.New("invalid duration format")
-				}
-				newValidUntil := time.Now().Add(d)
-				tasks[i].ValidUntil = &newValidUntil
-			}
+			tasks[i].ValidUntil = validUntil
+			recordChanges(&t, &tasks[i], now)
 			updated = true
 		}
 	}
@@ -257,7 +574,7 @@ func (m *Manager) UpdateGroupValidity(group string, validity string) error {
 }
 
 func (m *Manager) RemoveByGroup(group string) error {
-	tasks, err := m.storage.Load()
+	tasks, err := m.load()
 	if err != nil {
 		return err
 	}
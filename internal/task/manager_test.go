@@ -0,0 +1,147 @@
+package task
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// memStorage keeps tasks in memory.
+type memStorage struct {
+	tasks []Task
+	saves int
+}
+
+func (s *memStorage) Load() ([]Task, error) {
+	return append([]Task(nil), s.tasks...), nil
+}
+
+func (s *memStorage) Save(tasks []Task) error {
+	s.tasks = append([]Task(nil), tasks...)
+	s.saves++
+	return nil
+}
+
+func TestCleanupExpired(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	completed := now.Add(-2 * time.Hour)
+	archived := now.Add(-48 * time.Hour)
+
+	store := &memStorage{tasks: []Task{
+		{ID: 1, UUID: "a", Title: "expired open", Status: StatusTodo, ValidUntil: &past},
+		{ID: 2, UUID: "b", Title: "expired done", Status: StatusCompleted, CompletedAt: &completed, ValidUntil: &past},
+		{ID: 3, UUID: "c", Title: "still valid", Status: StatusTodo, ValidUntil: &future},
+		{ID: 4, UUID: "d", Title: "archived", Status: StatusTodo, ValidUntil: &past, ArchivedAt: &archived},
+		{ID: 5, UUID: "e", Title: "no validity", Status: StatusInProgress},
+	}}
+
+	if err := NewManager(store).CleanupExpired(); err != nil {
+		t.Fatalf("CleanupExpired: %v", err)
+	}
+
+	byID := make(map[int]Task)
+	for _, task := range store.tasks {
+		byID[task.ID] = task
+	}
+
+	tests := []struct {
+		id           int
+		kept         bool
+		nowArchived  bool
+		keepArchived bool
+	}{
+		{id: 1, kept: false},
+		{id: 2, kept: true, nowArchived: true},
+		{id: 3, kept: true},
+		{id: 4, kept: true, keepArchived: true},
+		{id: 5, kept: true},
+	}
+	for _, tt := range tests {
+		task, ok := byID[tt.id]
+		if ok != tt.kept {
+			t.Errorf("task %d kept = %v, want %v", tt.id, ok, tt.kept)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		switch {
+		case tt.nowArchived:
+			if task.ArchivedAt == nil {
+				t.Errorf("task %d was not archived", tt.id)
+			} else if len(task.History) == 0 || task.History[len(task.History)-1].Field != FieldArchivedAt {
+				t.Errorf("task %d archived without a history entry", tt.id)
+			}
+			if task.CompletedAt == nil || !task.CompletedAt.Equal(completed) {
+				t.Errorf("task %d lost its completion time", tt.id)
+			}
+		case tt.keepArchived:
+			if task.ArchivedAt == nil || !task.ArchivedAt.Equal(archived) {
+				t.Errorf("task %d archive time changed to %v", tt.id, task.ArchivedAt)
+			}
+		default:
+			if task.ArchivedAt != nil {
+				t.Errorf("task %d archived unexpectedly", tt.id)
+			}
+		}
+	}
+
+	saves := store.saves
+	if err := NewManager(store).CleanupExpired(); err != nil {
+		t.Fatalf("second CleanupExpired: %v", err)
+	}
+	if store.saves != saves {
+		t.Errorf("second CleanupExpired saved again with nothing to clean up")
+	}
+}
+
+func TestEditKeepsChangesMadeMeanwhile(t *testing.T) {
+	created := time.Now().Add(-time.Hour)
+	due := time.Now().Add(24 * time.Hour)
+	before := Task{ID: 1, UUID: "a", Title: "write report", Group: "work", Status: StatusTodo, CreatedAt: created, Due: &due, Tags: []string{"q3"}}
+	store := &memStorage{tasks: []Task{before}}
+	m := NewManager(store)
+
+	document, err := MarshalDocument(before)
+	if err != nil {
+		t.Fatalf("MarshalDocument: %v", err)
+	}
+	edited := []byte(strings.Replace(string(document), "title: write report", "title: write the report", 1) + "Outline first.\n")
+
+	// Saved while the editor was open
+	if err := m.Annotate(1, "asked for numbers"); err != nil {
+		t.Fatalf("Annotate: %v", err)
+	}
+	if err := m.Update(1, StatusInProgress); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	if err := m.Edit(before, edited); err != nil {
+		t.Fatalf("Edit: %v", err)
+	}
+
+	got := store.tasks[0]
+	if got.Title != "write the report" || got.Notes != "Outline first." {
+		t.Errorf("edited fields = %q, %q, want the document's", got.Title, got.Notes)
+	}
+	if got.Status != StatusInProgress {
+		t.Errorf("Status = %s, want the in-progress set while editing", got.Status)
+	}
+	if len(got.Annotations) != 1 {
+		t.Errorf("Annotations = %v, want the one added while editing", got.Annotations)
+	}
+	if got.Due == nil || !got.Due.Equal(due) || got.Group != "work" || len(got.Tags) != 1 {
+		t.Errorf("unedited fields changed: due %v, group %q, tags %v", got.Due, got.Group, got.Tags)
+	}
+
+	var fields []string
+	for _, c := range got.History {
+		fields = append(fields, c.Field)
+	}
+	if want := []string{FieldStatus, FieldTitle, FieldNotes}; !slices.Equal(fields, want) {
+		t.Errorf("History fields = %v, want %v", fields, want)
+	}
+}
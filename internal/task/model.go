@@ -13,11 +13,25 @@ const (
 )
 
 type Task struct {
-	ID          int        `json:"id"`
-	Title       string     `json:"title"`
-	Group       string     `json:"group"`
-	Status      TaskStatus `json:"status"`
-	CreatedAt   time.Time  `json:"created_at"`
-	CompletedAt *time.Time `json:"completed_at,omitempty"`
-	ValidUntil  *time.Time `json:"valid_until,omitempty"`
+	ID          int          `json:"id"`
+	UUID        string       `json:"uuid"`
+	Title       string       `json:"title"`
+	Group       string       `json:"group"`
+	Status      TaskStatus   `json:"status"`
+	CreatedAt   time.Time    `json:"created_at"`
+	CompletedAt *time.Time   `json:"completed_at,omitempty"`
+	ValidUntil  *time.Time   `json:"valid_until,omitempty"`
+	Due         *time.Time   `json:"due,omitempty"`
+	ArchivedAt  *time.Time   `json:"archived_at,omitempty"`
+	DependsOn   []string     `json:"depends_on,omitempty"`
+	Notes       string       `json:"notes,omitempty"`
+	Tags        []string     `json:"tags,omitempty"`
+	Annotations []Annotation `json:"annotations,omitempty"`
+	History     []Change     `json:"history,omitempty"`
+}
+
+// Annotation is a timestamped remark appended to a task.
+type Annotation struct {
+	Time time.Time `json:"time"`
+	Text string    `json:"text"`
 }
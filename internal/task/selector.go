@@ -0,0 +1,291 @@
+package task
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Selector picks tasks either by explicit references (short IDs, ranges such
+// as "3,5,9-14" or UUID prefixes) or by filter terms (e.g. "status:todo
+// group:work"). When both are given a task must be referenced and match
+// every filter term. Archived tasks are only matched when referenced
+// explicitly or requested with an "archived:" term.
+type Selector struct {
+	IDs          map[int]bool
+	Ranges       []IDRange
+	UUIDPrefixes []string
+	Status       []TaskStatus
+	Groups       []string
+	Tags         []string
+	Keyword      string
+	Due          string
+	// CompletedWithin keeps tasks completed less than this long ago.
+	CompletedWithin time.Duration
+	// IdleFor keeps tasks whose last change is at least this old.
+	IdleFor time.Duration
+	// Archived is one of archivedFilters, or empty for the default.
+	Archived string
+}
+
+// IDRange is an inclusive range of short IDs such as 9-14. Ranges are kept
+// as bounds rather than expanded, so huge ranges cost nothing.
+type IDRange struct {
+	From, To int
+}
+
+// Contains reports whether id is inside the range.
+func (r IDRange) Contains(id int) bool {
+	return id >= r.From && id <= r.To
+}
+
+// dueFilters are the values accepted by the "due:" filter term.
+var dueFilters = []string{"today", "tomorrow", "week", "overdue", "any", "none"}
+
+// archivedFilters are the values accepted by the "archived:" filter term.
+var archivedFilters = []string{"yes", "no", "any"}
+
+// ParseStatus converts user input into a TaskStatus.
+func ParseStatus(s string) (TaskStatus, error) {
+	switch strings.ToLower(s) {
+	case "todo", "pending":
+		return StatusTodo, nil
+	case "in-progress":
+		return StatusInProgress, nil
+	case "completed", "done":
+		return StatusCompleted, nil
+	}
+	return "", fmt.Errorf("invalid status '%s'. Use: todo, in-progress, completed", s)
+}
+
+// ParseSelector builds a Selector from command line arguments.
+func ParseSelector(args []string) (*Selector, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("no tasks selected")
+	}
+
+	sel := &Selector{}
+	for _, arg := range args {
+		key, value, isFilter := strings.Cut(arg, ":")
+		if !isFilter {
+			if isUUIDPrefix(arg) && strings.Trim(arg, "0123456789,-") != "" {
+				sel.UUIDPrefixes = append(sel.UUIDPrefixes, strings.ToLower(arg))
+				continue
+			}
+			if err := sel.addIDs(arg); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if value == "" {
+			return nil, fmt.Errorf("empty value in filter '%s'", arg)
+		}
+
+		switch strings.ToLower(key) {
+		case "status":
+			if strings.EqualFold(value, "open") {
+				sel.Status = append(sel.Status, StatusTodo, StatusInProgress)
+				continue
+			}
+			status, err := ParseStatus(value)
+			if err != nil {
+				return nil, err
+			}
+			sel.Status = append(sel.Status, status)
+		case "group":
+			sel.Groups = append(sel.Groups, value)
+		case "tag":
+			sel.Tags = append(sel.Tags, value)
+		case "due":
+			value = strings.ToLower(value)
+			if !slices.Contains(dueFilters, value) {
+				return nil, fmt.Errorf("invalid due filter '%s'. Use: %s", value, strings.Join(dueFilters, ", "))
+			}
+			sel.Due = value
+		case "completed":
+			d, err := ParseAge(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid duration in filter '%s'", arg)
+			}
+			sel.CompletedWithin = d
+		case "idle":
+			d, err := ParseAge(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid duration in filter '%s'", arg)
+			}
+			sel.IdleFor = d
+		case "archived":
+			value = strings.ToLower(value)
+			if !slices.Contains(archivedFilters, value) {
+				return nil, fmt.Errorf("invalid archived filter '%s'. Use: %s", value, strings.Join(archivedFilters, ", "))
+			}
+			sel.Archived = value
+		case "title":
+			sel.Keyword = strings.ToLower(value)
+		case "uuid":
+			if !isUUIDPrefix(value) {
+				return nil, fmt.Errorf("invalid UUID prefix '%s'", value)
+			}
+			sel.UUIDPrefixes = append(sel.UUIDPrefixes, strings.ToLower(value))
+		default:
+			return nil, fmt.Errorf("unknown filter '%s'. Use: status, group, tag, title, uuid, due, completed, idle, archived", key)
+		}
+	}
+
+	return sel, nil
+}
+
+// addIDs parses a comma separated list of IDs and ID ranges.
+func (s *Selector) addIDs(list string) error {
+	if s.IDs == nil {
+		s.IDs = make(map[int]bool)
+	}
+
+	for _, part := range strings.Split(list, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		if from, to, isRange := strings.Cut(part, "-"); isRange {
+			start, err1 := strconv.Atoi(from)
+			end, err2 := strconv.Atoi(to)
+			if err1 != nil || err2 != nil || start > end {
+				return fmt.Errorf("invalid ID range '%s'", part)
+			}
+			s.Ranges = append(s.Ranges, IDRange{From: start, To: end})
+			continue
+		}
+
+		id, err := strconv.Atoi(part)
+		if err != nil {
+			return fmt.Errorf("invalid task ID '%s'", part)
+		}
+		s.IDs[id] = true
+	}
+
+	return nil
+}
+
+// Match reports whether the task is selected.
+func (s *Selector) Match(t Task) bool {
+	hasReferences := s.IDs != nil || len(s.UUIDPrefixes) > 0
+	switch s.Archived {
+	case "yes":
+		if t.ArchivedAt == nil {
+			return false
+		}
+	case "no":
+		if t.ArchivedAt != nil {
+			return false
+		}
+	case "":
+		if t.ArchivedAt != nil && !hasReferences {
+			return false
+		}
+	}
+
+	if hasReferences {
+		referenced := s.IDs[t.ID]
+		for _, r := range s.Ranges {
+			if r.Contains(t.ID) {
+				referenced = true
+				break
+			}
+		}
+		for _, prefix := range s.UUIDPrefixes {
+			if strings.HasPrefix(t.UUID, prefix) {
+				referenced = true
+				break
+			}
+		}
+		if !referenced {
+			return false
+		}
+	}
+
+	if len(s.Status) > 0 {
+		matched := false
+		for _, status := range s.Status {
+			if t.Status == status {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if len(s.Groups) > 0 {
+		group := t.Group
+		if group == "" {
+			group = "General"
+		}
+		matched := false
+		for _, g := range s.Groups {
+			if strings.EqualFold(g, group) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	for _, tag := range s.Tags {
+		if !slices.ContainsFunc(t.Tags, func(have string) bool { return strings.EqualFold(have, tag) }) {
+			return false
+		}
+	}
+
+	if s.Keyword != "" && !strings.Contains(strings.ToLower(t.Title), s.Keyword) {
+		return false
+	}
+
+	now := time.Now()
+	if s.Due != "" && !matchDue(t, s.Due, now) {
+		return false
+	}
+
+	if s.CompletedWithin > 0 && (t.CompletedAt == nil || now.Sub(*t.CompletedAt) > s.CompletedWithin) {
+		return false
+	}
+
+	if s.IdleFor > 0 && now.Sub(LastChange(t)) < s.IdleFor {
+		return false
+	}
+
+	return true
+}
+
+// matchDue applies a "due:" filter value to a task.
+func matchDue(t Task, filter string, now time.Time) bool {
+	switch filter {
+	case "none":
+		return t.Due == nil
+	case "any":
+		return t.Due != nil
+	case "overdue":
+		return IsOverdue(t, now)
+	}
+
+	if t.Due == nil {
+		return false
+	}
+
+	switch filter {
+	case "today":
+		return !t.Due.After(EndOfDay(now))
+	case "tomorrow":
+		tomorrow := now.AddDate(0, 0, 1)
+		return !t.Due.Before(StartOfDay(tomorrow)) && !t.Due.After(EndOfDay(tomorrow))
+	case "week":
+		return !t.Due.After(EndOfDay(now.AddDate(0, 0, 7)))
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package task
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSelectorIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		match   []int
+		noMatch []int
+		wantErr bool
+	}{
+		{name: "single IDs", args: []string{"3,5"}, match: []int{3, 5}, noMatch: []int{4}},
+		{name: "range", args: []string{"9-11"}, match: []int{9, 10, 11}, noMatch: []int{8, 12}},
+		{name: "overlapping ranges", args: []string{"1-5,3-8"}, match: []int{1, 5, 6, 8}, noMatch: []int{9}},
+		{name: "range containing an ID", args: []string{"2-4,3"}, match: []int{2, 3, 4}, noMatch: []int{5}},
+		{name: "several arguments", args: []string{"1", "7-8"}, match: []int{1, 7, 8}, noMatch: []int{2}},
+		{name: "single element range", args: []string{"4-4"}, match: []int{4}, noMatch: []int{3, 5}},
+		{name: "huge range", args: []string{"1-300000000"}, match: []int{1, 299999999}, noMatch: []int{300000001}},
+		{name: "reversed range", args: []string{"5-3"}, wantErr: true},
+		{name: "open range", args: []string{"3-"}, wantErr: true},
+		{name: "bad ID", args: []string{"3,x"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sel, err := ParseSelector(tt.args)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseSelector(%q) succeeded, want an error", tt.args)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSelector(%q): %v", tt.args, err)
+			}
+			for _, id := range tt.match {
+				if !sel.Match(Task{ID: id}) {
+					t.Errorf("task %d not matched by %q", id, tt.args)
+				}
+			}
+			for _, id := range tt.noMatch {
+				if sel.Match(Task{ID: id}) {
+					t.Errorf("task %d matched by %q", id, tt.args)
+				}
+			}
+		})
+	}
+}
+
+func TestSelectorFiltersAndArchived(t *testing.T) {
+	archived := time.Now()
+	tasks := []Task{
+		{ID: 1, Status: StatusTodo, Group: "work"},
+		{ID: 2, Status: StatusCompleted, Group: "work"},
+		{ID: 3, Status: StatusTodo, Group: ""},
+		{ID: 4, Status: StatusTodo, Group: "work", ArchivedAt: &archived},
+	}
+
+	tests := []struct {
+		args []string
+		want []int
+	}{
+		{args: []string{"status:todo"}, want: []int{1, 3}},
+		{args: []string{"group:general"}, want: []int{3}},
+		{args: []string{"group:work", "status:open"}, want: []int{1}},
+		{args: []string{"1-4", "group:work"}, want: []int{1, 2, 4}},
+		{args: []string{"archived:yes"}, want: []int{4}},
+		{args: []string{"2-3,1-2"}, want: []int{1, 2, 3}},
+	}
+
+	for _, tt := range tests {
+		sel, err := ParseSelector(tt.args)
+		if err != nil {
+			t.Fatalf("ParseSelector(%q): %v", tt.args, err)
+		}
+		var got []int
+		for _, task := range tasks {
+			if sel.Match(task) {
+				got = append(got, task.ID)
+			}
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%q selected %v, want %v", tt.args, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%q selected %v, want %v", tt.args, got, tt.want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package task
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SortKey is one field of a multi-key sort.
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+// sortFields compares two tasks on a single field, returning a negative
+// number, zero or a positive number like strings.Compare.
+var sortFields = map[string]func(a, b Task) int{
+	"id":    func(a, b Task) int { return a.ID - b.ID },
+	"uuid":  func(a, b Task) int { return strings.Compare(a.UUID, b.UUID) },
+	"title": func(a, b Task) int { return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title)) },
+	"group": func(a, b Task) int {
+		return strings.Compare(strings.ToLower(groupName(a)), strings.ToLower(groupName(b)))
+	},
+	"status":    func(a, b Task) int { return statusRank(a.Status) - statusRank(b.Status) },
+	"created":   func(a, b Task) int { return a.CreatedAt.Compare(b.CreatedAt) },
+	"completed": func(a, b Task) int { return compareTimes(a.CompletedAt, b.CompletedAt) },
+	"valid":     func(a, b Task) int { return compareTimes(a.ValidUntil, b.ValidUntil) },
+	"due":       func(a, b Task) int { return compareTimes(a.Due, b.Due) },
+}
+
+// SortFieldNames lists the fields tasks can be sorted by.
+func SortFieldNames() []string {
+	names := make([]string, 0, len(sortFields))
+	for name := range sortFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseSort parses a comma separated sort specification such as
+// "status,-created" or "group:asc,created:desc". A leading '-' or a
+// ":desc" suffix sorts the field in descending order.
+func ParseSort(spec string) ([]SortKey, error) {
+	var keys []SortKey
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+
+		key := SortKey{}
+		if strings.HasPrefix(part, "-") {
+			key.Desc = true
+			part = part[1:]
+		} else if strings.HasPrefix(part, "+") {
+			part = part[1:]
+		}
+
+		if field, dir, ok := strings.Cut(part, ":"); ok {
+			switch dir {
+			case "asc":
+			case "desc":
+				key.Desc = true
+			default:
+				return nil, fmt.Errorf("invalid sort direction '%s'. Use: asc, desc", dir)
+			}
+			part = field
+		}
+
+		if _, ok := sortFields[part]; !ok {
+			return nil, fmt.Errorf("unknown sort field '%s'. Use: %s", part, strings.Join(SortFieldNames(), ", "))
+		}
+		key.Field = part
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// SortTasks sorts tasks in place by the given keys. Ties keep their
+// storage order.
+func SortTasks(tasks []Task, keys []SortKey) {
+	if len(keys) == 0 {
+		return
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		for _, key := range keys {
+			c := sortFields[key.Field](tasks[i], tasks[j])
+			if c == 0 {
+				continue
+			}
+			if key.Desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+}
+
+func groupName(t Task) string {
+	if t.Group == "" {
+		return "General"
+	}
+	return t.Group
+}
+
+// statusRank orders statuses along the task lifecycle.
+func statusRank(s TaskStatus) int {
+	switch s {
+	case StatusTodo:
+		return 0
+	case StatusInProgress:
+		return 1
+	case StatusCompleted:
+		return 2
+	}
+	return 3
+}
+
+// compareTimes orders unset times after set ones.
+func compareTimes(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	return a.Compare(*b)
+}
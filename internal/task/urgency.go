@@ -0,0 +1,131 @@
+package task
+
+import (
+	"math"
+	"time"
+)
+
+// Urgency weights. Due dates dominate, followed by work already in
+// progress, tasks that unblock others and the checked out group.
+const (
+	urgencyOverdue        = 12.0
+	urgencyDueToday       = 9.0
+	urgencyDueSoon        = 6.0
+	urgencyDueThisWeek    = 3.0
+	urgencyInProgress     = 4.0
+	urgencyPerBlocked     = 2.0
+	urgencyMaxBlocking    = 6.0
+	urgencyCurrentGroup   = 3.0
+	urgencyExpiringSoon   = 2.0
+	urgencyAgePerDay      = 0.1
+	urgencyMaxAge         = 2.0
+	urgencyMaxOverdueDays = 7.0
+)
+
+// Dependencies indexes tasks by UUID to answer blocking questions.
+type Dependencies struct {
+	byUUID   map[string]Task
+	blocking map[string]int
+}
+
+// NewDependencies builds the dependency index for a task list.
+func NewDependencies(tasks []Task) *Dependencies {
+	d := &Dependencies{
+		byUUID:   make(map[string]Task, len(tasks)),
+		blocking: make(map[string]int),
+	}
+	for _, t := range tasks {
+		d.byUUID[t.UUID] = t
+	}
+	for _, t := range tasks {
+		if t.Status == StatusCompleted {
+			continue
+		}
+		for _, dep := range t.DependsOn {
+			d.blocking[dep]++
+		}
+	}
+	return d
+}
+
+// Lookup returns the task with the given UUID.
+func (d *Dependencies) Lookup(uuid string) (Task, bool) {
+	t, ok := d.byUUID[uuid]
+	return t, ok
+}
+
+// BlockedBy returns the unfinished tasks t depends on. Dependencies that
+// were removed no longer block.
+func (d *Dependencies) BlockedBy(t Task) []Task {
+	var blockers []Task
+	for _, dep := range t.DependsOn {
+		if other, ok := d.byUUID[dep]; ok && other.Status != StatusCompleted {
+			blockers = append(blockers, other)
+		}
+	}
+	return blockers
+}
+
+// Blocking returns how many open tasks wait for t.
+func (d *Dependencies) Blocking(t Task) int {
+	return d.blocking[t.UUID]
+}
+
+// Urgency scores how pressing an open task is; higher is more urgent.
+// Completed, archived and blocked tasks score zero.
+func Urgency(t Task, deps *Dependencies, currentGroup string, now time.Time) float64 {
+	if t.Status == StatusCompleted || t.ArchivedAt != nil || len(deps.BlockedBy(t)) > 0 {
+		return 0
+	}
+
+	score := 0.0
+
+	if t.Due != nil {
+		switch {
+		case t.Due.Before(now):
+			overdueDays := now.Sub(*t.Due).Hours() / 24
+			score += urgencyOverdue + math.Min(overdueDays, urgencyMaxOverdueDays)
+		case !t.Due.After(EndOfDay(now)):
+			score += urgencyDueToday
+		case t.Due.Sub(now) <= 3*24*time.Hour:
+			score += urgencyDueSoon
+		case t.Due.Sub(now) <= 7*24*time.Hour:
+			score += urgencyDueThisWeek
+		}
+	}
+
+	if t.Status == StatusInProgress {
+		score += urgencyInProgress
+	}
+
+	score += math.Min(float64(deps.Blocking(t))*urgencyPerBlocked, urgencyMaxBlocking)
+
+	if currentGroup != "" && groupName(t) == currentGroup {
+		score += urgencyCurrentGroup
+	}
+
+	if t.ValidUntil != nil && t.ValidUntil.Sub(now) < 24*time.Hour {
+		score += urgencyExpiringSoon
+	}
+
+	ageDays := now.Sub(t.CreatedAt).Hours() / 24
+	score += math.Min(ageDays*urgencyAgePerDay, urgencyMaxAge)
+
+	return score
+}
+
+// Next returns the most urgent open, unarchived, unblocked task. ok is false when
+// there is nothing to work on.
+func Next(tasks []Task, currentGroup string, now time.Time) (next Task, score float64, ok bool) {
+	deps := NewDependencies(tasks)
+	for _, t := range tasks {
+		if t.Status == StatusCompleted || t.ArchivedAt != nil || len(deps.BlockedBy(t)) > 0 {
+			continue
+		}
+		s := Urgency(t, deps, currentGroup, now)
+		if !ok || s > score || s == score && t.CreatedAt.Before(next.CreatedAt) {
+			next, score, ok = t, s, true
+		}
+	}
+	return next, score, ok
+}
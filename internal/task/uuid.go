@@ -0,0 +1,34 @@
+package task
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strings"
+)
+
+// minUUIDPrefix is the shortest UUID prefix accepted as a task reference.
+const minUUIDPrefix = 4
+
+// newUUID returns a random (version 4) UUID.
+func newUUID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// isUUIDPrefix reports whether s looks like the start of a UUID.
+func isUUIDPrefix(s string) bool {
+	if len(s) < minUUIDPrefix || len(s) > 36 {
+		return false
+	}
+	for _, r := range strings.ToLower(s) {
+		if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'f' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
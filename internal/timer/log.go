@@ -0,0 +1,87 @@
+package timer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/MohakGupta2004/taskgo/internal/config"
+)
+
+// Kinds of timer runs kept in the log.
+const (
+	KindPomodoro = "pomodoro"
+	KindWork     = "work"
+	KindBreak    = "break"
+)
+
+// Record is a single timer run kept in the pomodoro log.
+type Record struct {
+	Start     time.Time     `json:"start"`
+	End       time.Time     `json:"end"`
+	Planned   time.Duration `json:"planned"`
+	Title     string        `json:"title"`
+	Kind      string        `json:"kind"`
+	Completed bool          `json:"completed"`
+	TaskUUID  string        `json:"task_uuid,omitempty"`
+}
+
+// IsFocus reports whether the run was focused work rather than a break.
+func (r Record) IsFocus() bool {
+	return r.Kind != KindBreak
+}
+
+// LogPath returns the location of the pomodoro log.
+func LogPath() (string, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "pomodoros.json"), nil
+}
+
+// LoadLog reads all recorded timer runs.
+func LoadLog() ([]Record, error) {
+	path, err := LogPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return []Record{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var records []Record
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// AppendLog adds a timer run to the log.
+func AppendLog(r Record) error {
+	records, err := LoadLog()
+	if err != nil {
+		return err
+	}
+	records = append(records, r)
+
+	path, err := LogPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
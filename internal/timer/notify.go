@@ -0,0 +1,67 @@
+package timer
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/MohakGupta2004/taskgo/internal/audio"
+)
+
+// Notifier tells how the end of a timer is announced.
+type Notifier struct {
+	// Beeps is how often the notification sound is played.
+	Beeps int
+	// Desktop shows a desktop notification as well.
+	Desktop bool
+	// Command is run with the shell; the timer title is in $TASKGO_TIMER.
+	Command string
+}
+
+// Notify is used by every timer; the commands set it from the config file.
+var Notify = Notifier{Beeps: 3}
+
+// announce tells the user that the timer is over.
+func (n Notifier) announce(title string) {
+	if n.Desktop {
+		if cmd := desktopNotification(title); cmd != nil {
+			cmd.Run()
+		}
+	}
+	if n.Command != "" {
+		cmd := shellCommand(n.Command)
+		cmd.Env = append(os.Environ(), "TASKGO_TIMER="+title)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			fmt.Println("Notifier command failed: " + err.Error())
+		}
+	}
+	if n.Beeps > 0 {
+		audio.PlayMultipleBeeps(n.Beeps)
+	}
+}
+
+// desktopNotification returns the command showing a notification, or nil
+// if the platform has none.
+func desktopNotification(title string) *exec.Cmd {
+	message := title + " finished"
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("osascript", "-e", fmt.Sprintf("display notification %q with title \"taskgo\"", message))
+	case "windows":
+		return nil
+	}
+	if _, err := exec.LookPath("notify-send"); err != nil {
+		return nil
+	}
+	return exec.Command("notify-send", "taskgo", message)
+}
+
+func shellCommand(command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", command)
+	}
+	return exec.Command("sh", "-c", command)
+}
@@ -5,9 +5,9 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"sync"
 	"time"
 
-	"github.com/MohakGupta2004/taskgo/internal/audio"
 	"github.com/MohakGupta2004/taskgo/internal/ui"
 )
 
@@ -15,8 +15,52 @@ import (
 type Timer struct {
 	Duration time.Duration
 	Title    string
+	// Details is shown below the countdown, e.g. the tasks to work on.
+	Details string
+	// Finished is set when the countdown ran to zero rather than being quit.
+	Finished bool
+	// Pauses records when the timer was paused.
+	Pauses   []Pause
 	paused   bool
 	stopChan chan struct{}
+	stopOnce sync.Once
+}
+
+// Pause is a stretch of time a timer spent paused.
+type Pause struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Paused returns how long the timer has been paused in total.
+func (t *Timer) Paused() time.Duration {
+	var total time.Duration
+	for _, p := range t.Pauses {
+		total += p.End.Sub(p.Start)
+	}
+	return total
+}
+
+// keys delivers key presses from stdin. A single reader goroutine feeds it
+// for the life of the process so that a timer which has returned does not
+// leave a reader behind that swallows later input.
+var (
+	keys      = make(chan rune, 16)
+	keyReader sync.Once
+)
+
+func readKeys() {
+	go func() {
+		reader := bufio.NewReader(os.Stdin)
+		for {
+			char, _, err := reader.ReadRune()
+			if err != nil {
+				close(keys)
+				return
+			}
+			keys <- char
+		}
+	}()
 }
 
 // New creates a new Timer
@@ -33,22 +77,15 @@ func (t *Timer) Start() {
 	// Disable input buffering to read single keys
 	disableInputBuffering()
 	defer enableInputBuffering()
+	defer t.endPause()
 
 	targetTime := time.Now().Add(t.Duration)
 	ticker := time.NewTicker(1 * time.Second)
 	defer ticker.Stop()
 
-	// Channel to receive key presses
-	keyChan := make(chan rune)
-	go func() {
-		reader := bufio.NewReader(os.Stdin)
-		for {
-			char, _, err := reader.ReadRune()
-			if err == nil {
-				keyChan <- char
-			}
-		}
-	}()
+	// Channel to receive key presses; nil once stdin is closed
+	keyReader.Do(readKeys)
+	keyChan := keys
 
 	// Initial render
 	t.render(t.Duration)
@@ -59,16 +96,22 @@ func (t *Timer) Start() {
 		select {
 		case <-t.stopChan:
 			return
-		case key := <-keyChan:
+		case key, ok := <-keyChan:
+			if !ok {
+				keyChan = nil
+				continue
+			}
 			if key == 'p' || key == 'P' {
 				t.paused = !t.paused
 				if t.paused {
 					// Adjust targetTime when pausing so we don't lose time
 					// But actually, when paused, we just stop updating remaining
 					// When resuming, we need to recalculate targetTime
+					t.Pauses = append(t.Pauses, Pause{Start: time.Now()})
 				} else {
 					// Resuming: reset targetTime based on remaining
 					targetTime = time.Now().Add(remaining)
+					t.endPause()
 				}
 				t.render(remaining)
 			} else if key == 'q' || key == 'Q' { // Optional: q to quit
@@ -92,6 +135,36 @@ func (t *Timer) Start() {
 	}
 }
 
+// Stop ends a running timer early, as if it had been quit. It is safe to
+// call from another goroutine, e.g. a signal handler, and more than once.
+func (t *Timer) Stop() {
+	t.stopOnce.Do(func() { close(t.stopChan) })
+}
+
+// endPause closes a pause that is still open.
+func (t *Timer) endPause() {
+	if n := len(t.Pauses); n > 0 && t.Pauses[n-1].End.IsZero() {
+		t.Pauses[n-1].End = time.Now()
+	}
+}
+
+// AskKey prints a question and returns the next key pressed, or 0 when
+// stdin is closed. Use it instead of reading stdin directly once a timer
+// has run, since the timer's reader owns stdin from then on.
+func AskKey(question string) rune {
+	fmt.Print(question)
+	disableInputBuffering()
+	defer enableInputBuffering()
+
+	keyReader.Do(readKeys)
+	key, ok := <-keys
+	fmt.Println()
+	if !ok {
+		return 0
+	}
+	return key
+}
+
 func (t *Timer) render(remaining time.Duration) {
 	// Clear screen
 	fmt.Print("\033[H\033[2J")
@@ -117,15 +190,21 @@ func (t *Timer) render(remaining time.Duration) {
 		fmt.Println("")
 	}
 
+	if t.Details != "" {
+		fmt.Println(t.Details)
+		fmt.Println("")
+	}
+
 	fmt.Println("")
 	fmt.Println(ui.SecondaryStyle.Render("Press 'p' to pause/resume, Ctrl+C to exit"))
 }
 
 func (t *Timer) finish() {
+	t.Finished = true
 	fmt.Print("\033[H\033[2J") // Clear screen
 	fmt.Println(ui.SuccessStyle.Render("🎉 Timer finished! 🎉"))
 	fmt.Println("")
-	audio.PlayMultipleBeeps(3)
+	Notify.announce(t.Title)
 }
 
 // Helper functions for raw mode
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// calendarCellWidth fits a two digit day, a count of up to two digits and
+// the gap to the next column.
+const calendarCellWidth = 6
+
+// Calendar renders a month grid, weeks starting on Monday, with the value
+// of count next to each day. Days for which overdue returns true show
+// their count in red.
+func Calendar(month time.Time, now time.Time, count func(day time.Time) int, overdue func(day time.Time) bool) string {
+	first := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	// Monday is column 0.
+	offset := (int(first.Weekday()) + 6) % 7
+
+	var lines []string
+	title := first.Format("January 2006")
+	gridWidth := 7*calendarCellWidth - 1
+	lines = append(lines, TreeBranchStyle.Render(strings.Repeat(" ", max((gridWidth-len(title))/2, 0))+title))
+
+	var header strings.Builder
+	for _, name := range []string{"Mo", "Tu", "We", "Th", "Fr", "Sa", "Su"} {
+		header.WriteString(fmt.Sprintf("%-*s", calendarCellWidth, " "+name))
+	}
+	lines = append(lines, SecondaryStyle.Render(strings.TrimRight(header.String(), " ")))
+
+	var row strings.Builder
+	row.WriteString(strings.Repeat(" ", offset*calendarCellWidth))
+	col := offset
+	for day := first; day.Month() == first.Month(); day = day.AddDate(0, 0, 1) {
+		label := fmt.Sprintf("%2d", day.Day())
+		if sameDay(day, now) {
+			label = TodayStyle.Render(label)
+		} else if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			label = SecondaryStyle.Render(label)
+		}
+
+		countLabel := "  "
+		if c := count(day); c > 0 {
+			style := WarningStyle
+			if overdue(day) {
+				style = ErrorStyle
+			}
+			countLabel = style.Render(fmt.Sprintf("%-2d", c))
+		}
+
+		row.WriteString(label + " " + countLabel + " ")
+		col++
+		if col == 7 {
+			lines = append(lines, strings.TrimRight(row.String(), " "))
+			row.Reset()
+			col = 0
+		}
+	}
+	if col > 0 {
+		lines = append(lines, strings.TrimRight(row.String(), " "))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
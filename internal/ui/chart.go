@@ -0,0 +1,201 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// heatmapColors go from "no activity" to "most active".
+var heatmapColors = []lipgloss.Color{
+	lipgloss.Color("#3A3F44"),
+	lipgloss.Color("#0E4429"),
+	lipgloss.Color("#006D32"),
+	lipgloss.Color("#26A641"),
+	lipgloss.Color("#39D353"),
+}
+
+// Sparkline renders values as a single line of block characters scaled to
+// the largest value. Zero is drawn as the lowest block so gaps stay visible.
+func Sparkline(values []int) string {
+	maxValue := 0
+	for _, v := range values {
+		maxValue = max(maxValue, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		if maxValue == 0 || v <= 0 {
+			b.WriteRune(sparkBlocks[0])
+			continue
+		}
+		idx := 1 + (v*(len(sparkBlocks)-1)-1)/maxValue
+		b.WriteRune(sparkBlocks[min(idx, len(sparkBlocks)-1)])
+	}
+	return PrimaryStyle.Render(b.String())
+}
+
+// Heatmap renders a GitHub-style contribution grid for the last weeks
+// weeks: one column per week, one row per weekday, ending today.
+func Heatmap(count func(day time.Time) int, weeks int, now time.Time) string {
+	y, m, d := now.Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+	// Start on the Sunday weeks-1 weeks before this week's Sunday.
+	start := today.AddDate(0, 0, -int(today.Weekday())-7*(weeks-1))
+
+	maxCount := 0
+	for day := start; !day.After(today); day = day.AddDate(0, 0, 1) {
+		maxCount = max(maxCount, count(day))
+	}
+
+	// Month labels above the first week of each month.
+	months := make([]rune, weeks*2)
+	for i := range months {
+		months[i] = ' '
+	}
+	for w := 0; w < weeks; w++ {
+		weekStart := start.AddDate(0, 0, 7*w)
+		if weekStart.Day() <= 7 && w*2+3 <= len(months) {
+			copy(months[w*2:], []rune(weekStart.Format("Jan")))
+		}
+	}
+
+	var lines []string
+	lines = append(lines, "    "+SecondaryStyle.Render(strings.TrimRight(string(months), " ")))
+
+	labels := []string{"   ", "Mon", "   ", "Wed", "   ", "Fri", "   "}
+	for weekday := 0; weekday < 7; weekday++ {
+		var b strings.Builder
+		b.WriteString(SecondaryStyle.Render(labels[weekday]) + " ")
+		for w := 0; w < weeks; w++ {
+			day := start.AddDate(0, 0, 7*w+weekday)
+			if day.After(today) {
+				break
+			}
+			level := 0
+			if c := count(day); c > 0 && maxCount > 0 {
+				level = 1 + (c*(len(heatmapColors)-1)-1)/maxCount
+			}
+			b.WriteString(lipgloss.NewStyle().Foreground(heatmapColors[level]).Render("■") + " ")
+		}
+		lines = append(lines, strings.TrimRight(b.String(), " "))
+	}
+
+	var legend strings.Builder
+	legend.WriteString(SecondaryStyle.Render("    Less "))
+	for _, c := range heatmapColors {
+		legend.WriteString(lipgloss.NewStyle().Foreground(c).Render("■") + " ")
+	}
+	legend.WriteString(SecondaryStyle.Render("More"))
+	lines = append(lines, legend.String())
+
+	return strings.Join(lines, "\n")
+}
+
+// LineChart plots values as a line of height rows with a y axis. Each value
+// takes colWidth characters.
+func LineChart(values []int, height, colWidth int) string {
+	if len(values) == 0 || height < 2 {
+		return ""
+	}
+
+	maxValue := 1
+	for _, v := range values {
+		maxValue = max(maxValue, v)
+	}
+
+	grid := make([][]rune, height)
+	for r := range grid {
+		grid[r] = []rune(strings.Repeat(" ", len(values)*colWidth))
+	}
+
+	rowOf := func(v int) int {
+		return (v*(height-1) + maxValue/2) / maxValue
+	}
+
+	prev := -1
+	for i, v := range values {
+		row := rowOf(v)
+		col := i * colWidth
+		if prev >= 0 {
+			for r := min(prev, row) + 1; r < max(prev, row); r++ {
+				grid[r][col] = '│'
+			}
+		}
+		grid[row][col] = '●'
+		prev = row
+	}
+
+	axisWidth := len(strconv.Itoa(maxValue))
+	var lines []string
+	for r := height - 1; r >= 0; r-- {
+		label := strings.Repeat(" ", axisWidth)
+		if r == height-1 {
+			label = padLeft(strconv.Itoa(maxValue), axisWidth)
+		} else if r == 0 {
+			label = padLeft("0", axisWidth)
+		}
+		lines = append(lines, SecondaryStyle.Render(label+" ┤")+PrimaryStyle.Render(strings.TrimRight(string(grid[r]), " ")))
+	}
+	lines = append(lines, SecondaryStyle.Render(strings.Repeat(" ", axisWidth)+" └"+strings.Repeat("─", len(values)*colWidth)))
+	return strings.Join(lines, "\n")
+}
+
+// StackedChart draws stacked columns, one per index of the layers, with
+// the first layer at the bottom. Each layer is drawn in its own color.
+func StackedChart(layers [][]int, colors []lipgloss.Color, height, colWidth int) string {
+	if len(layers) == 0 || len(layers[0]) == 0 || height < 1 {
+		return ""
+	}
+
+	points := len(layers[0])
+	maxTotal := 1
+	for i := 0; i < points; i++ {
+		total := 0
+		for _, layer := range layers {
+			total += layer[i]
+		}
+		maxTotal = max(maxTotal, total)
+	}
+
+	axisWidth := len(strconv.Itoa(maxTotal))
+	var lines []string
+	for r := height - 1; r >= 0; r-- {
+		var b strings.Builder
+		label := strings.Repeat(" ", axisWidth)
+		if r == height-1 {
+			label = padLeft(strconv.Itoa(maxTotal), axisWidth)
+		} else if r == 0 {
+			label = padLeft("0", axisWidth)
+		}
+		b.WriteString(SecondaryStyle.Render(label + " ┤"))
+
+		for i := 0; i < points; i++ {
+			cell := strings.Repeat(" ", colWidth)
+			cumulative := 0
+			for l, layer := range layers {
+				cumulative += layer[i]
+				// A row is filled by the first layer whose scaled top reaches it.
+				if (cumulative*height+maxTotal/2)/maxTotal > r {
+					cell = lipgloss.NewStyle().Foreground(colors[l%len(colors)]).Render(strings.Repeat("█", colWidth))
+					break
+				}
+			}
+			b.WriteString(cell)
+		}
+		lines = append(lines, strings.TrimRight(b.String(), " "))
+	}
+	lines = append(lines, SecondaryStyle.Render(strings.Repeat(" ", axisWidth)+" └"+strings.Repeat("─", points*colWidth)))
+	return strings.Join(lines, "\n")
+}
+
+func padLeft(s string, width int) string {
+	if len(s) >= width {
+		return s
+	}
+	return strings.Repeat(" ", width-len(s)) + s
+}
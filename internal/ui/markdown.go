@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	MarkdownHeadingStyle = lipgloss.NewStyle().
+				Foreground(PrimaryColor).
+				Bold(true)
+
+	MarkdownCodeStyle = lipgloss.NewStyle().
+				Foreground(OrangeColor)
+
+	MarkdownQuoteStyle = lipgloss.NewStyle().
+				Foreground(SecondaryColor).
+				Italic(true)
+
+	MarkdownLinkStyle = lipgloss.NewStyle().
+				Foreground(PrimaryColor).
+				Underline(true)
+
+	markdownCode   = regexp.MustCompile("`([^`]+)`")
+	markdownBold   = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	markdownItalic = regexp.MustCompile(`\*([^*]+)\*|\b_([^_]+)_\b`)
+	markdownLink   = regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
+	markdownList   = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	markdownOrder  = regexp.MustCompile(`^(\s*)(\d+)[.)]\s+(.*)$`)
+)
+
+// RenderMarkdown renders a small subset of Markdown (headings, lists,
+// quotes, fenced code, emphasis, inline code and links) for the terminal.
+// Paragraphs are wrapped to width when it is positive.
+func RenderMarkdown(text string, width int) string {
+	var out []string
+	inCode := false
+
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			out = append(out, MarkdownCodeStyle.Render("    "+line))
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(trimmed, "#"):
+			heading := strings.TrimSpace(strings.TrimLeft(trimmed, "#"))
+			out = append(out, MarkdownHeadingStyle.Render(heading))
+		case strings.HasPrefix(trimmed, ">"):
+			quote := strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))
+			out = append(out, MarkdownQuoteStyle.Render("│ "+renderInline(quote)))
+		case markdownList.MatchString(line):
+			m := markdownList.FindStringSubmatch(line)
+			out = append(out, wrap(m[1]+"  • ", renderInline(m[2]), width))
+		case markdownOrder.MatchString(line):
+			m := markdownOrder.FindStringSubmatch(line)
+			out = append(out, wrap(m[1]+"  "+m[2]+". ", renderInline(m[3]), width))
+		default:
+			out = append(out, wrap("", renderInline(line), width))
+		}
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// renderInline styles emphasis, inline code and links within a line.
+func renderInline(s string) string {
+	s = markdownCode.ReplaceAllStringFunc(s, func(m string) string {
+		return MarkdownCodeStyle.Render(strings.Trim(m, "`"))
+	})
+	s = markdownLink.ReplaceAllStringFunc(s, func(m string) string {
+		parts := markdownLink.FindStringSubmatch(m)
+		return parts[1] + " (" + MarkdownLinkStyle.Render(parts[2]) + ")"
+	})
+	s = markdownBold.ReplaceAllStringFunc(s, func(m string) string {
+		parts := markdownBold.FindStringSubmatch(m)
+		return lipgloss.NewStyle().Bold(true).Render(parts[1] + parts[2])
+	})
+	s = markdownItalic.ReplaceAllStringFunc(s, func(m string) string {
+		parts := markdownItalic.FindStringSubmatch(m)
+		return lipgloss.NewStyle().Italic(true).Render(parts[1] + parts[2])
+	})
+	return s
+}
+
+// wrap renders text with a hanging indent of prefix's width.
+func wrap(prefix, text string, width int) string {
+	if width <= 0 {
+		return prefix + text
+	}
+
+	indent := lipgloss.Width(prefix)
+	body := lipgloss.NewStyle().Width(width - indent).Render(text)
+	lines := strings.Split(body, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+		if i == 0 {
+			lines[i] = prefix + lines[i]
+		} else {
+			lines[i] = strings.Repeat(" ", indent) + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -1,69 +1,187 @@
 package ui
 
 import (
+	"sort"
+
 	"github.com/charmbracelet/lipgloss"
 )
 
+// Theme is the set of colors the styles are built from.
+type Theme struct {
+	Primary   lipgloss.Color
+	Secondary lipgloss.Color
+	Success   lipgloss.Color
+	Warning   lipgloss.Color
+	Error     lipgloss.Color
+	Pending   lipgloss.Color
+}
+
+// Themes are the built-in themes that can be picked in the config file.
+var Themes = map[string]Theme{
+	"default": {
+		Primary:   "#007BFF",
+		Secondary: "#6C757D",
+		Success:   "#28A745",
+		Warning:   "#FFC107",
+		Error:     "#DC3545",
+		Pending:   "#FFA500",
+	},
+	"nord": {
+		Primary:   "#88C0D0",
+		Secondary: "#4C566A",
+		Success:   "#A3BE8C",
+		Warning:   "#EBCB8B",
+		Error:     "#BF616A",
+		Pending:   "#D08770",
+	},
+	"mono": {
+		Primary:   "15",
+		Secondary: "244",
+		Success:   "250",
+		Warning:   "15",
+		Error:     "15",
+		Pending:   "250",
+	},
+}
+
+// ThemeNames returns the names of the built-in themes in order.
+func ThemeNames() []string {
+	names := make([]string, 0, len(Themes))
+	for name := range Themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WithColors returns the theme with the named colors replaced. Names are
+// those of the config file: primary, secondary, success, warning, error and
+// pending.
+func (t Theme) WithColors(colors map[string]string) Theme {
+	for name, value := range colors {
+		color := lipgloss.Color(value)
+		switch name {
+		case "primary":
+			t.Primary = color
+		case "secondary":
+			t.Secondary = color
+		case "success":
+			t.Success = color
+		case "warning":
+			t.Warning = color
+		case "error":
+			t.Error = color
+		case "pending":
+			t.Pending = color
+		}
+	}
+	return t
+}
+
 var (
-	PrimaryColor   = lipgloss.Color("#007BFF")
-	SecondaryColor = lipgloss.Color("#6C757D")
-	SuccessColor   = lipgloss.Color("#28A745")
-	WarningColor   = lipgloss.Color("#FFC107")
-	ErrorColor     = lipgloss.Color("#DC3545")
+	PrimaryColor   lipgloss.Color
+	SecondaryColor lipgloss.Color
+	SuccessColor   lipgloss.Color
+	WarningColor   lipgloss.Color
+	ErrorColor     lipgloss.Color
 	LightGray      = lipgloss.Color("#F8F9FA")
 	DarkGray       = lipgloss.Color("#343A40")
-	OrangeColor    = lipgloss.Color("#FFA500")
+	OrangeColor    lipgloss.Color
+
+	PrimaryStyle   lipgloss.Style
+	SecondaryStyle lipgloss.Style
+	SuccessStyle   lipgloss.Style
+	WarningStyle   lipgloss.Style
+	ErrorStyle     lipgloss.Style
+	InfoStyle      lipgloss.Style
+
+	TitleStyle            lipgloss.Style
+	TableHeaderStyle      lipgloss.Style
+	TableCellStyle        lipgloss.Style
+	StatusTodoStyle       lipgloss.Style
+	StatusInProgressStyle lipgloss.Style
+	StatusCompletedStyle  lipgloss.Style
+	PendingRowStyle       lipgloss.Style
+	InProgressRowStyle    lipgloss.Style
+	CompletedRowStyle     lipgloss.Style
+	TreeBranchStyle       lipgloss.Style
+	HighlightStyle        lipgloss.Style
+	TodayStyle            lipgloss.Style
+	BannerStyle           lipgloss.Style
+)
+
+func init() {
+	ApplyTheme(Themes["default"])
+}
+
+// ApplyTheme rebuilds all styles from the colors of t.
+func ApplyTheme(t Theme) {
+	PrimaryColor = t.Primary
+	SecondaryColor = t.Secondary
+	SuccessColor = t.Success
+	WarningColor = t.Warning
+	ErrorColor = t.Error
+	OrangeColor = t.Pending
 
-	PrimaryStyle   = lipgloss.NewStyle().Foreground(PrimaryColor)
+	PrimaryStyle = lipgloss.NewStyle().Foreground(PrimaryColor)
 	SecondaryStyle = lipgloss.NewStyle().Foreground(SecondaryColor)
-	SuccessStyle   = lipgloss.NewStyle().Foreground(SuccessColor)
-	WarningStyle   = lipgloss.NewStyle().Foreground(WarningColor)
-	ErrorStyle     = lipgloss.NewStyle().Foreground(ErrorColor)
-	InfoStyle      = lipgloss.NewStyle().Foreground(PrimaryColor)
+	SuccessStyle = lipgloss.NewStyle().Foreground(SuccessColor)
+	WarningStyle = lipgloss.NewStyle().Foreground(WarningColor)
+	ErrorStyle = lipgloss.NewStyle().Foreground(ErrorColor)
+	InfoStyle = lipgloss.NewStyle().Foreground(PrimaryColor)
 
 	TitleStyle = lipgloss.NewStyle().
-			Foreground(PrimaryColor).
-			Bold(true).
-			Padding(1, 2).
-			BorderStyle(lipgloss.RoundedBorder()).
-			BorderForeground(PrimaryColor)
+		Foreground(PrimaryColor).
+		Bold(true).
+		Padding(1, 2).
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(PrimaryColor)
 
 	TableHeaderStyle = lipgloss.NewStyle().
-				Foreground(DarkGray).
-				Background(LightGray).
-				Bold(true).
-				Padding(0, 1)
+		Foreground(DarkGray).
+		Background(LightGray).
+		Bold(true).
+		Padding(0, 1)
 
 	TableCellStyle = lipgloss.NewStyle().
-			Padding(0, 1)
+		Padding(0, 1)
 
 	StatusTodoStyle = lipgloss.NewStyle().
-			Foreground(OrangeColor)
+		Foreground(OrangeColor)
 
 	StatusInProgressStyle = lipgloss.NewStyle().
-				Foreground(PrimaryColor)
+		Foreground(PrimaryColor)
 
 	StatusCompletedStyle = lipgloss.NewStyle().
-				Foreground(SuccessColor)
+		Foreground(SuccessColor)
 
 	PendingRowStyle = lipgloss.NewStyle().
-			Foreground(OrangeColor)
+		Foreground(OrangeColor)
 
 	InProgressRowStyle = lipgloss.NewStyle().
-				Foreground(PrimaryColor)
+		Foreground(PrimaryColor)
 
 	CompletedRowStyle = lipgloss.NewStyle().
-				Foreground(SuccessColor)
+		Foreground(SuccessColor)
 
 	TreeBranchStyle = lipgloss.NewStyle().
-			Foreground(SecondaryColor).
-			Bold(true)
+		Foreground(SecondaryColor).
+		Bold(true)
+
+	HighlightStyle = lipgloss.NewStyle().
+		Foreground(WarningColor).
+		Bold(true).
+		Underline(true)
+
+	TodayStyle = lipgloss.NewStyle().
+		Reverse(true).
+		Bold(true)
 
 	BannerStyle = lipgloss.NewStyle().
-			Foreground(PrimaryColor).
-			Bold(true).
-			MarginBottom(1)
-)
+		Foreground(PrimaryColor).
+		Bold(true).
+		MarginBottom(1)
+}
 
 func RenderTitle(title string) string {
 	return TitleStyle.Render(title)
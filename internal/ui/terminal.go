@@ -0,0 +1,23 @@
+package ui
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// DefaultTerminalWidth is used when the output is not a terminal.
+const DefaultTerminalWidth = 120
+
+// TerminalWidth returns the width of the terminal attached to stdout,
+// honouring $COLUMNS and falling back to DefaultTerminalWidth.
+func TerminalWidth() int {
+	if w, _, err := term.GetSize(os.Stdout.Fd()); err == nil && w > 0 {
+		return w
+	}
+	if w, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && w > 0 {
+		return w
+	}
+	return DefaultTerminalWidth
+}